@@ -1,6 +1,10 @@
 package gomap_test
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/lindsaygelle/gomap"
@@ -118,3 +122,266 @@ func BenchmarkValues(b *testing.B) {
 		newMap.Values()
 	}
 }
+
+func BenchmarkImmutableMapSet(b *testing.B) {
+	immutableMap := gomap.NewImmutableMap[int, int]()
+	for i := 0; i < 1000; i++ {
+		immutableMap = immutableMap.Set(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		immutableMap.Set(i%1000, i)
+	}
+}
+
+func BenchmarkImmutableMapBuilder(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		builder := gomap.NewImmutableMapBuilder[int, int]()
+		for j := 0; j < 1000; j++ {
+			builder.Set(j, j)
+		}
+		builder.Build()
+	}
+}
+
+func BenchmarkCOWMapAdd(b *testing.B) {
+	cowMap := gomap.NewCOWMap[int, int]()
+	for i := 0; i < 100000; i++ {
+		cowMap.Add(i, i)
+	}
+	snapshot := cowMap.Snapshot()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cowMap.Add(i%100000, i)
+	}
+	_ = snapshot
+}
+
+func BenchmarkCounterMostCommon(b *testing.B) {
+	counter := gomap.NewCounter[int]()
+	for i := 0; i < 10000; i++ {
+		counter.IncBy(i, i%97)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		counter.MostCommon(10)
+	}
+}
+
+func BenchmarkTrieMapWithPrefix(b *testing.B) {
+	trieMap := gomap.NewTrieMap[int]()
+	for i := 0; i < 10000; i++ {
+		trieMap.Add(fmt.Sprintf("/api/v%d/resource/%d", i%10, i), i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trieMap.WithPrefix("/api/v3/resource/12")
+	}
+}
+
+func BenchmarkMapPrefixScan(b *testing.B) {
+	newMap := &gomap.Map[string, int]{}
+	for i := 0; i < 10000; i++ {
+		newMap.Add(fmt.Sprintf("/api/v%d/resource/%d", i%10, i), i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newMap.Filter(func(key string, value int) bool {
+			return strings.HasPrefix(key, "/api/v3/resource/12")
+		})
+	}
+}
+
+func BenchmarkTrieMapBuild(b *testing.B) {
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("/api/v%d/resource/%d", i%10, i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trieMap := gomap.NewTrieMap[int]()
+		for j, key := range keys {
+			trieMap.Add(key, j)
+		}
+	}
+}
+
+func BenchmarkMapBuild(b *testing.B) {
+	keys := make([]string, 10000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("/api/v%d/resource/%d", i%10, i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newMap := &gomap.Map[string, int]{}
+		for j, key := range keys {
+			newMap.Add(key, j)
+		}
+	}
+}
+
+// benchmarkValue is a comparable struct value used by the equality benchmarks.
+type benchmarkValue struct {
+	ID   int
+	Name string
+}
+
+// newEqualityMaps returns two equal maps of size n with values created by fn.
+func newEqualityMaps[V any](n int, fn func(i int) V) (*gomap.Map[int, V], *gomap.Map[int, V]) {
+	a, b := &gomap.Map[int, V]{}, &gomap.Map[int, V]{}
+	for i := 0; i < n; i++ {
+		a.Add(i, fn(i))
+		b.Add(i, fn(i))
+	}
+	return a, b
+}
+
+func benchmarkEqual[V comparable](b *testing.B, fn func(i int) V) {
+	x, y := newEqualityMaps(1000, fn)
+	b.Run("DeepEqual", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			x.EqualFunc(y, func(a V, b V) bool {
+				return reflect.DeepEqual(a, b)
+			})
+		}
+	})
+	b.Run("Equal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			x.Equal(y)
+		}
+	})
+	b.Run("EqualComparable", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			gomap.EqualComparable(x, y)
+		}
+	})
+}
+
+func BenchmarkEqualInt(b *testing.B) {
+	benchmarkEqual(b, func(i int) int { return i })
+}
+
+func BenchmarkEqualString(b *testing.B) {
+	benchmarkEqual(b, func(i int) string { return fmt.Sprint("value-", i) })
+}
+
+func BenchmarkEqualStruct(b *testing.B) {
+	benchmarkEqual(b, func(i int) benchmarkValue { return benchmarkValue{i, fmt.Sprint("value-", i)} })
+}
+
+func benchmarkContains[V comparable](b *testing.B, fn func(i int) V) {
+	x, _ := newEqualityMaps(1000, fn)
+	missing := fn(-1)
+	b.Run("DeepEqual", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			x.EachBreak(func(key int, value V) bool {
+				return !reflect.DeepEqual(value, missing)
+			})
+		}
+	})
+	b.Run("Contains", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			x.Contains(missing)
+		}
+	})
+	b.Run("ContainsComparable", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			gomap.ContainsComparable(x, missing)
+		}
+	})
+}
+
+func BenchmarkContainsInt(b *testing.B) {
+	benchmarkContains(b, func(i int) int { return i })
+}
+
+func BenchmarkContainsString(b *testing.B) {
+	benchmarkContains(b, func(i int) string { return fmt.Sprint("value-", i) })
+}
+
+func BenchmarkContainsStruct(b *testing.B) {
+	benchmarkContains(b, func(i int) benchmarkValue { return benchmarkValue{i, fmt.Sprint("value-", i)} })
+}
+
+// work simulates a CPU-heavy callback.
+func work(value int) int {
+	for i := 0; i < 1000; i++ {
+		value = value*31 + i
+	}
+	return value
+}
+
+func BenchmarkMapSequential(b *testing.B) {
+	newMap := &gomap.Map[int, int]{}
+	for i := 0; i < 1000; i++ {
+		newMap.Add(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newMap.Map(func(key int, value int) int {
+			return work(value)
+		})
+	}
+}
+
+func BenchmarkParallelMap(b *testing.B) {
+	newMap := &gomap.Map[int, int]{}
+	for i := 0; i < 1000; i++ {
+		newMap.Add(i, i)
+	}
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newMap.ParallelMap(ctx, 0, func(ctx context.Context, key int, value int) (int, error) {
+			return work(value), nil
+		})
+	}
+}
+
+func BenchmarkFilterSequential(b *testing.B) {
+	newMap := &gomap.Map[int, int]{}
+	for i := 0; i < 1000; i++ {
+		newMap.Add(i, i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newMap.Filter(func(key int, value int) bool {
+			return work(value)%2 == 0
+		})
+	}
+}
+
+func BenchmarkParallelFilter(b *testing.B) {
+	newMap := &gomap.Map[int, int]{}
+	for i := 0; i < 1000; i++ {
+		newMap.Add(i, i)
+	}
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		newMap.ParallelFilter(ctx, 0, func(ctx context.Context, key int, value int) (bool, error) {
+			return work(value)%2 == 0, nil
+		})
+	}
+}
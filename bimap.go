@@ -0,0 +1,159 @@
+package gomap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lindsaygelle/slice"
+)
+
+// ErrValueCollision is returned by BiMap.Add when the value is already associated with another key
+// and the BiMap uses the CollisionError policy.
+var ErrValueCollision = errors.New("gomap: value is already associated with another key")
+
+// CollisionPolicy controls how a BiMap handles adding a value that is already associated with another key.
+type CollisionPolicy int
+
+const (
+	// CollisionError rejects the pair and returns ErrValueCollision.
+	CollisionError CollisionPolicy = iota
+	// CollisionOverwrite removes the existing pair holding the value before adding the new pair.
+	CollisionOverwrite
+	// CollisionReject silently rejects the pair.
+	CollisionReject
+)
+
+// BiMap is a bidirectional map that maintains a one-to-one association between keys and values,
+// allowing values to be looked up by key and keys to be looked up by value in constant time.
+type BiMap[K comparable, V comparable] struct {
+	forward  *Map[K, V]
+	inverse  *Map[V, K]
+	inversed *BiMap[V, K]
+	policy   CollisionPolicy
+}
+
+// NewBiMap creates an empty BiMap that resolves value collisions using the provided policy.
+//
+//	// Create a new BiMap instance.
+//	biMap := gomap.NewBiMap[int, string](gomap.CollisionError)
+//	biMap.Add(1, "apple")
+//	key, ok := biMap.GetByValue("apple") // 1, true
+func NewBiMap[K comparable, V comparable](policy CollisionPolicy) *BiMap[K, V] {
+	biMap := &BiMap[K, V]{forward: &Map[K, V]{}, inverse: &Map[V, K]{}, policy: policy}
+	biMap.inversed = &BiMap[V, K]{forward: biMap.inverse, inverse: biMap.forward, inversed: biMap, policy: policy}
+	return biMap
+}
+
+// Add associates the key with the value in both directions and reports whether the pair was added.
+// If the key was associated with another value, that association is replaced. If the value is associated
+// with another key, the outcome depends on the CollisionPolicy of the BiMap.
+//
+//	// Create a new BiMap instance.
+//	biMap := gomap.NewBiMap[int, string](gomap.CollisionError)
+//	ok, err := biMap.Add(1, "apple")  // true, nil
+//	ok, err = biMap.Add(2, "apple")   // false, ErrValueCollision
+func (biMap *BiMap[K, V]) Add(key K, value V) (bool, error) {
+	if existingKey, ok := biMap.inverse.Get(value); ok && existingKey != key {
+		switch biMap.policy {
+		case CollisionOverwrite:
+			biMap.forward.Delete(existingKey)
+		case CollisionReject:
+			return false, nil
+		default:
+			return false, fmt.Errorf("%w: %v is associated with %v", ErrValueCollision, value, existingKey)
+		}
+	}
+	if existingValue, ok := biMap.forward.Get(key); ok {
+		biMap.inverse.Delete(existingValue)
+	}
+	biMap.forward.Add(key, value)
+	biMap.inverse.Add(value, key)
+	return true, nil
+}
+
+// Delete removes the pair with the provided key and reports whether it was present.
+//
+//	// Create a new BiMap instance.
+//	biMap := gomap.NewBiMap[int, string](gomap.CollisionError)
+//	biMap.Add(1, "apple")
+//	ok := biMap.Delete(1) // true, "apple" can no longer be looked up either
+func (biMap *BiMap[K, V]) Delete(key K) bool {
+	value, ok := biMap.forward.PopOK(key)
+	if ok {
+		biMap.inverse.Delete(value)
+	}
+	return ok
+}
+
+// DeleteByValue removes the pair with the provided value and reports whether it was present.
+//
+//	// Create a new BiMap instance.
+//	biMap := gomap.NewBiMap[int, string](gomap.CollisionError)
+//	biMap.Add(1, "apple")
+//	ok := biMap.DeleteByValue("apple") // true
+func (biMap *BiMap[K, V]) DeleteByValue(value V) bool {
+	return biMap.inversed.Delete(value)
+}
+
+// Each executes the provided function for each key-value pair in the map.
+func (biMap *BiMap[K, V]) Each(fn func(key K, value V)) *BiMap[K, V] {
+	biMap.forward.Each(fn)
+	return biMap
+}
+
+// Fetch retrieves the value associated with the given key, or the zero value for the value type if the key is not present.
+func (biMap *BiMap[K, V]) Fetch(key K) V {
+	return biMap.forward.Fetch(key)
+}
+
+// Get retrieves the value associated with the provided key and a boolean indicating whether the key exists.
+func (biMap *BiMap[K, V]) Get(key K) (V, bool) {
+	return biMap.forward.Get(key)
+}
+
+// GetByValue retrieves the key associated with the provided value and a boolean indicating whether the value exists.
+//
+//	// Create a new BiMap instance.
+//	biMap := gomap.NewBiMap[int, string](gomap.CollisionError)
+//	biMap.Add(1, "apple")
+//	key, ok := biMap.GetByValue("apple") // 1, true
+func (biMap *BiMap[K, V]) GetByValue(value V) (K, bool) {
+	return biMap.inverse.Get(value)
+}
+
+// Has checks if the provided key exists in the map.
+func (biMap *BiMap[K, V]) Has(key K) bool {
+	return biMap.forward.Has(key)
+}
+
+// HasValue checks if the provided value exists in the map.
+func (biMap *BiMap[K, V]) HasValue(value V) bool {
+	return biMap.inverse.Has(value)
+}
+
+// Inverse returns a live view of the BiMap with keys and values swapped.
+// Changes made through the inverse are reflected in the BiMap and vice versa.
+//
+//	// Create a new BiMap instance.
+//	biMap := gomap.NewBiMap[int, string](gomap.CollisionError)
+//	biMap.Add(1, "apple")
+//	biMap.Inverse().Add("banana", 2)
+//	value := biMap.Fetch(2) // "banana"
+func (biMap *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	return biMap.inversed
+}
+
+// Keys returns a slice containing all the keys present in the map.
+func (biMap *BiMap[K, V]) Keys() *slice.Slice[K] {
+	return biMap.forward.Keys()
+}
+
+// Length returns the number of key-value pairs in the map.
+func (biMap *BiMap[K, V]) Length() int {
+	return biMap.forward.Length()
+}
+
+// Values returns a slice containing all the values present in the map.
+func (biMap *BiMap[K, V]) Values() *slice.Slice[V] {
+	return biMap.inverse.Keys()
+}
@@ -0,0 +1,129 @@
+package gomap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// assertBiMapConsistent checks that the forward and inverse directions of the BiMap agree.
+func assertBiMapConsistent[K comparable, V comparable](t *testing.T, biMap *gomap.BiMap[K, V]) {
+	t.Helper()
+	if biMap.Length() != biMap.Inverse().Length() {
+		t.Fatalf("Expected both directions to have the same length, but got %d and %d", biMap.Length(), biMap.Inverse().Length())
+	}
+	biMap.Each(func(key K, value V) {
+		if k, ok := biMap.GetByValue(value); !ok || k != key {
+			t.Fatalf("Expected value %v to map back to key %v, but got %v", value, key, k)
+		}
+	})
+}
+
+// TestBiMapAdd tests BiMap.Add.
+func TestBiMapAdd(t *testing.T) {
+	biMap := gomap.NewBiMap[int, string](gomap.CollisionError)
+
+	// Test case 1: Add pairs and look them up in both directions.
+	if ok, err := biMap.Add(1, "apple"); !ok || err != nil {
+		t.Fatalf("Expected Add to succeed, but got %t, %v", ok, err)
+	}
+	biMap.Add(2, "banana")
+	if key, ok := biMap.GetByValue("banana"); !ok || key != 2 {
+		t.Errorf("Expected 'banana' to map to 2, but got %v", key)
+	}
+	if value, ok := biMap.Get(1); !ok || value != "apple" {
+		t.Errorf("Expected 1 to map to 'apple', but got %v", value)
+	}
+
+	// Test case 2: Replacing the value of a key removes the old inverse entry.
+	biMap.Add(1, "cherry")
+	if biMap.HasValue("apple") {
+		t.Errorf("Expected 'apple' to be removed from the inverse")
+	}
+	assertBiMapConsistent(t, biMap)
+
+	// Test case 3: Re-adding the same pair is not a collision.
+	if ok, err := biMap.Add(1, "cherry"); !ok || err != nil {
+		t.Errorf("Expected re-adding an existing pair to succeed, but got %t, %v", ok, err)
+	}
+
+	// Test case 4: Adding a value held by another key returns an error.
+	ok, err := biMap.Add(3, "banana")
+	if ok || !errors.Is(err, gomap.ErrValueCollision) {
+		t.Errorf("Expected ErrValueCollision, but got %t, %v", ok, err)
+	}
+	if biMap.Has(3) {
+		t.Errorf("Expected key 3 not to be added")
+	}
+	assertBiMapConsistent(t, biMap)
+}
+
+// TestBiMapCollisionPolicy tests the CollisionOverwrite and CollisionReject policies.
+func TestBiMapCollisionPolicy(t *testing.T) {
+	overwrite := gomap.NewBiMap[int, string](gomap.CollisionOverwrite)
+	overwrite.Add(1, "apple")
+	if ok, err := overwrite.Add(2, "apple"); !ok || err != nil {
+		t.Errorf("Expected overwrite to succeed, but got %t, %v", ok, err)
+	}
+	if overwrite.Has(1) || overwrite.Fetch(2) != "apple" {
+		t.Errorf("Expected key 1 to be replaced by key 2")
+	}
+	assertBiMapConsistent(t, overwrite)
+
+	reject := gomap.NewBiMap[int, string](gomap.CollisionReject)
+	reject.Add(1, "apple")
+	if ok, err := reject.Add(2, "apple"); ok || err != nil {
+		t.Errorf("Expected reject to return false without error, but got %t, %v", ok, err)
+	}
+	if !reject.Has(1) || reject.Has(2) {
+		t.Errorf("Expected key 1 to be kept")
+	}
+	assertBiMapConsistent(t, reject)
+}
+
+// TestBiMapDelete tests BiMap.Delete and BiMap.DeleteByValue.
+func TestBiMapDelete(t *testing.T) {
+	biMap := gomap.NewBiMap[int, string](gomap.CollisionError)
+	biMap.Add(1, "apple")
+	biMap.Add(2, "banana")
+
+	if !biMap.Delete(1) || biMap.HasValue("apple") {
+		t.Errorf("Expected Delete to remove both directions")
+	}
+	if biMap.Delete(1) {
+		t.Errorf("Expected Delete of a missing key to return false")
+	}
+	if !biMap.DeleteByValue("banana") || biMap.Has(2) {
+		t.Errorf("Expected DeleteByValue to remove both directions")
+	}
+	if biMap.Length() != 0 {
+		t.Errorf("Expected empty map, but got length %d", biMap.Length())
+	}
+}
+
+// TestBiMapInverse tests BiMap.Inverse.
+func TestBiMapInverse(t *testing.T) {
+	biMap := gomap.NewBiMap[int, string](gomap.CollisionError)
+	inverse := biMap.Inverse()
+	biMap.Add(1, "apple")
+	inverse.Add("banana", 2)
+
+	if biMap.Fetch(2) != "banana" || inverse.Fetch("apple") != 1 {
+		t.Errorf("Expected inverse to be a live view")
+	}
+	if inverse.Inverse() != biMap {
+		t.Errorf("Expected inverse of the inverse to be the original map")
+	}
+	if _, err := inverse.Add("cherry", 1); !errors.Is(err, gomap.ErrValueCollision) {
+		t.Errorf("Expected inverse to share the collision policy, but got %v", err)
+	}
+	inverse.Delete("apple")
+	if biMap.Has(1) {
+		t.Errorf("Expected deletion through the inverse to be visible")
+	}
+	if !biMap.Values().Contains("banana") || !biMap.Keys().Contains(2) {
+		t.Errorf("Expected keys and values to reflect the remaining pair")
+	}
+	assertBiMapConsistent(t, biMap)
+}
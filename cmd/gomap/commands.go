@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// change is a value that differs between the two objects passed to diff.
+type change struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// diffResult is the output of diff.
+type diffResult struct {
+	Added   map[string]any    `json:"added"`
+	Changed map[string]change `json:"changed"`
+	Removed map[string]any    `json:"removed"`
+}
+
+// diffFlags reports the keys added, removed and changed between two objects,
+// returning errDifferences if there are any.
+func diffFlags(flagSet *flag.FlagSet) func(env *env, args []string) error {
+	return func(env *env, args []string) error {
+		if len(args) != 2 {
+			return fmt.Errorf("expected two files, but got %d", len(args))
+		}
+		objects, err := env.readAll(args)
+		if err != nil {
+			return err
+		}
+		a, b := gomap.Map[string, any](objects[0]), gomap.Map[string, any](objects[1])
+		result := diffResult{Added: map[string]any{}, Changed: map[string]change{}, Removed: map[string]any{}}
+		a.Each(func(key string, value any) {
+			other, ok := b.Get(key)
+			switch {
+			case !ok:
+				result.Removed[key] = value
+			case !equalValues(value, other):
+				result.Changed[key] = change{From: value, To: other}
+			}
+		})
+		b.Each(func(key string, value any) {
+			if a.Not(key) {
+				result.Added[key] = value
+			}
+		})
+		if err := env.write(result); err != nil {
+			return err
+		}
+		if len(result.Added)+len(result.Changed)+len(result.Removed) > 0 {
+			return errDifferences
+		}
+		return nil
+	}
+}
+
+// filterFlags keeps the key-value pairs whose values match the query passed to --where.
+func filterFlags(flagSet *flag.FlagSet) func(env *env, args []string) error {
+	where := flagSet.String("where", "", "query `expr`ession that values must match, such as 'age >= 18 && active'")
+	return func(env *env, args []string) error {
+		if *where == "" {
+			return errors.New("--where is required")
+		}
+		query, err := gomap.CompileQuery(*where)
+		if err != nil {
+			return err
+		}
+		object, err := env.readOne(args)
+		if err != nil {
+			return err
+		}
+		return env.write((*gomap.Map[string, any])(&object).Filter(gomap.QueryFunc[string, any](query)))
+	}
+}
+
+// flattenFlags flattens nested objects into dot-separated keys.
+func flattenFlags(flagSet *flag.FlagSet) func(env *env, args []string) error {
+	return func(env *env, args []string) error {
+		object, err := env.readOne(args)
+		if err != nil {
+			return err
+		}
+		return env.write(gomap.Flatten((*gomap.Map[string, any])(&object)))
+	}
+}
+
+// intersectFlags keeps the key-value pairs present and equal in every object, keeping the values of the first object.
+func intersectFlags(flagSet *flag.FlagSet) func(env *env, args []string) error {
+	return func(env *env, args []string) error {
+		objects, err := env.readAll(args)
+		if err != nil {
+			return err
+		}
+		result := (*gomap.Map[string, any])(&objects[0])
+		for _, object := range objects[1:] {
+			result = result.IntersectionFunc((*gomap.Map[string, any])(&object), func(key string, a any, b any) bool {
+				return equalValues(a, b)
+			})
+		}
+		return env.write(result)
+	}
+}
+
+// invertFlags swaps the keys and values of an object. Values must be strings, numbers, booleans or null,
+// and are converted to keys using their JSON representation, except for strings which are used as they are.
+func invertFlags(flagSet *flag.FlagSet) func(env *env, args []string) error {
+	return func(env *env, args []string) error {
+		object, err := env.readOne(args)
+		if err != nil {
+			return err
+		}
+		keys := sortedKeys(object)
+		inverted := make(gomap.Map[string, string], len(object))
+		for _, key := range keys {
+			var invertedKey string
+			switch value := object[key].(type) {
+			case string:
+				invertedKey = value
+			case nil:
+				invertedKey = "null"
+			case json.Number:
+				invertedKey = value.String()
+			case bool:
+				invertedKey = fmt.Sprint(value)
+			default:
+				return fmt.Errorf("cannot invert %T value of %q", value, key)
+			}
+			if existing, ok := inverted.Get(invertedKey); ok {
+				return fmt.Errorf("cannot invert: %q and %q have the same value %q", existing, key, invertedKey)
+			}
+			inverted.Add(invertedKey, key)
+		}
+		return env.write(inverted)
+	}
+}
+
+// keysFlags lists the keys of an object in sorted order.
+func keysFlags(flagSet *flag.FlagSet) func(env *env, args []string) error {
+	return func(env *env, args []string) error {
+		object, err := env.readOne(args)
+		if err != nil {
+			return err
+		}
+		return env.write(sortedKeys(object))
+	}
+}
+
+// mergeFlags merges objects from left to right, replacing earlier values with later ones.
+// With --deep, nested objects are merged recursively using gomap.DeepMerge.
+func mergeFlags(flagSet *flag.FlagSet) func(env *env, args []string) error {
+	deep := flagSet.Bool("deep", false, "merge nested objects recursively")
+	return func(env *env, args []string) error {
+		objects, err := env.readAll(args)
+		if err != nil {
+			return err
+		}
+		result := make(gomap.Map[string, any])
+		for _, object := range objects {
+			other := (*gomap.Map[string, any])(&object)
+			if !*deep {
+				result.Merge(other)
+			} else if err := gomap.DeepMerge(&result, other); err != nil {
+				return err
+			}
+		}
+		return env.write(result)
+	}
+}
+
+// valuesFlags lists the values of an object ordered by their keys.
+func valuesFlags(flagSet *flag.FlagSet) func(env *env, args []string) error {
+	return func(env *env, args []string) error {
+		object, err := env.readOne(args)
+		if err != nil {
+			return err
+		}
+		values := make([]any, 0, len(object))
+		for _, key := range sortedKeys(object) {
+			values = append(values, object[key])
+		}
+		return env.write(values)
+	}
+}
+
+// equalValues checks if two decoded values are equal, comparing numbers by their value rather than their text,
+// so that 1, 1.0 and 1e0 are equal. Objects and arrays are compared element by element.
+func equalValues(a any, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		return ok && equalNumbers(a, b)
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, value := range a {
+			other, ok := b[key]
+			if !ok || !equalValues(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		b, ok := b.([]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !equalValues(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// equalNumbers checks if two numbers are equal. They are parsed with enough precision to distinguish any two
+// different numbers of their length, so large integers are compared exactly.
+func equalNumbers(a json.Number, b json.Number) bool {
+	if a == b {
+		return true
+	}
+	precision := uint(max(len(a), len(b)))*4 + 64
+	x, _, errX := big.ParseFloat(string(a), 10, precision, big.ToNearestEven)
+	y, _, errY := big.ParseFloat(string(b), 10, precision, big.ToNearestEven)
+	return errX == nil && errY == nil && x.Cmp(y) == 0
+}
+
+// sortedKeys returns the keys of the object in sorted order.
+func sortedKeys(object map[string]any) []string {
+	keys := []string(*(*gomap.Map[string, any])(&object).Keys())
+	sort.Strings(keys)
+	return keys
+}
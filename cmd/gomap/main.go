@@ -0,0 +1,219 @@
+// Command gomap manipulates JSON objects from the command line using the gomap library.
+//
+// Usage:
+//
+//	gomap <command> [flags] [file ...]
+//
+// Each command reads JSON or YAML objects from the named files, or from standard input if no files are named
+// or a file is named "-", and writes its result to standard output as indented JSON with sorted keys.
+// Standard input can be named at most once. Files named *.yaml or *.yml are read as YAML, as are other inputs
+// that do not start with '{'. YAML is read by a built-in parser for the subset used by configuration files:
+// block and single-line flow collections, plain, quoted and block scalars, and comments. Anchors, aliases,
+// tags and multiple documents are reported as errors. Each input must hold exactly one object.
+//
+// Numbers are kept as they are written, so large integers are not rounded, and are compared by their value,
+// so 1 and 1.0 are equal.
+//
+// The commands are:
+//
+//	merge [--deep] file ...    merge the objects from left to right; later values replace earlier ones
+//	diff a b                   report the keys added, removed and changed between two objects
+//	intersect file ...         keep the key-value pairs present and equal in every object
+//	filter --where expr [file] keep the key-value pairs whose values match the query expression
+//	keys [file]                list the keys of the object
+//	values [file]              list the values of the object, ordered by key
+//	invert [file]              swap the keys and values of an object whose values are scalars
+//	flatten [file]             flatten nested objects into dot-separated keys
+//
+// The exit status is 0 on success, 1 if diff found differences, and 2 if an error occurred.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Exit statuses.
+const (
+	exitOK          = 0
+	exitDifferences = 1
+	exitError       = 2
+)
+
+// errDifferences is returned by commands that found differences between their inputs.
+var errDifferences = errors.New("differences found")
+
+// command runs a subcommand with its flags and arguments, writing its result to env.stdout.
+type command struct {
+	flags func(flagSet *flag.FlagSet) func(env *env, args []string) error
+	usage string
+}
+
+// commands maps the name of each subcommand to its implementation.
+var commands = map[string]command{
+	"diff":      {flags: diffFlags, usage: "diff a b"},
+	"filter":    {flags: filterFlags, usage: "filter --where expr [file]"},
+	"flatten":   {flags: flattenFlags, usage: "flatten [file]"},
+	"intersect": {flags: intersectFlags, usage: "intersect file ..."},
+	"invert":    {flags: invertFlags, usage: "invert [file]"},
+	"keys":      {flags: keysFlags, usage: "keys [file]"},
+	"merge":     {flags: mergeFlags, usage: "merge [--deep] file ..."},
+	"values":    {flags: valuesFlags, usage: "values [file]"},
+}
+
+// env holds the standard streams of a command.
+type env struct {
+	stderr io.Writer
+	stdin  io.Reader
+	stdout io.Writer
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command named by the first argument and returns the exit status.
+func run(args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return exitError
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "gomap: unknown command %q\n", args[0])
+		usage(stderr)
+		return exitError
+	}
+	flagSet := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	flagSet.Usage = func() {
+		fmt.Fprintf(stderr, "usage: gomap %s\n", cmd.usage)
+		flagSet.PrintDefaults()
+	}
+	fn := cmd.flags(flagSet)
+	if err := flagSet.Parse(args[1:]); err != nil {
+		return exitError
+	}
+	err := fn(&env{stderr: stderr, stdin: stdin, stdout: stdout}, flagSet.Args())
+	switch {
+	case errors.Is(err, errDifferences):
+		return exitDifferences
+	case err != nil:
+		fmt.Fprintf(stderr, "gomap %s: %v\n", args[0], err)
+		return exitError
+	}
+	return exitOK
+}
+
+// usage writes the list of commands.
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: gomap <command> [flags] [file ...]")
+	fmt.Fprintln(w, "commands:")
+	for _, name := range []string{"merge", "diff", "intersect", "filter", "keys", "values", "invert", "flatten"} {
+		fmt.Fprintf(w, "  gomap %s\n", commands[name].usage)
+	}
+}
+
+// read decodes the JSON or YAML object in the named file, or in standard input if the name is "-".
+func (env *env) read(name string) (map[string]any, error) {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(env.stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]any
+	if isYAML(name, data) {
+		object, err = decodeYAML(data)
+	} else {
+		object, err = decodeJSON(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return object, nil
+}
+
+// isYAML checks if the named input is YAML, either by its extension or, for standard input and files
+// with other extensions, because it does not start with a JSON object.
+func isYAML(name string, data []byte) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		return true
+	case ".json":
+		return false
+	}
+	return !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("{"))
+}
+
+// decodeJSON decodes a JSON object, decoding numbers as json.Number. Anything after the object is an error.
+func decodeJSON(data []byte) (map[string]any, error) {
+	var object map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&object); err != nil {
+		return nil, err
+	}
+	if object == nil {
+		return nil, errors.New("expected a JSON object")
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unexpected data after the object at offset %d", decoder.InputOffset())
+	}
+	return object, nil
+}
+
+// readAll decodes the objects in the named files, or in standard input if no files are named.
+// Numbers are decoded as json.Number.
+func (env *env) readAll(names []string) ([]map[string]any, error) {
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+	objects := make([]map[string]any, len(names))
+	stdin := false
+	for i, name := range names {
+		if name == "-" {
+			if stdin {
+				return nil, errors.New("standard input can only be read once")
+			}
+			stdin = true
+		}
+		object, err := env.read(name)
+		if err != nil {
+			return nil, err
+		}
+		objects[i] = object
+	}
+	return objects, nil
+}
+
+// readOne decodes the object in the named file, or in standard input if no file is named.
+func (env *env) readOne(names []string) (map[string]any, error) {
+	if len(names) > 1 {
+		return nil, fmt.Errorf("expected at most one file, but got %d", len(names))
+	}
+	objects, err := env.readAll(names)
+	if err != nil {
+		return nil, err
+	}
+	return objects[0], nil
+}
+
+// write encodes the value to standard output as indented JSON. Object keys are sorted by the encoder.
+func (env *env) write(value any) error {
+	encoder := json.NewEncoder(env.stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes the named JSON documents to a temporary directory and returns their paths.
+func writeFiles(t *testing.T, documents ...string) []string {
+	dir := t.TempDir()
+	paths := make([]string, len(documents))
+	for i, document := range documents {
+		paths[i] = filepath.Join(dir, string(rune('a'+i))+".json")
+		if err := os.WriteFile(paths[i], []byte(document), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return paths
+}
+
+// TestRun tests the output and exit status of each command.
+func TestRun(t *testing.T) {
+	files := writeFiles(t,
+		`{"a": 1, "b": {"c": 2}, "d": "x"}`,
+		`{"a": 1, "b": {"c": 3}, "e": true}`,
+	)
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected string
+		status   int
+	}{
+		{"diff", []string{"diff", files[0], files[1]}, "", `{"added":{"e":true},"changed":{"b":{"from":{"c":2},"to":{"c":3}}},"removed":{"d":"x"}}`, exitDifferences},
+		{"diff equal", []string{"diff", files[0], "-"}, `{"d": "x", "b": {"c": 2}, "a": 1}`, `{"added":{},"changed":{},"removed":{}}`, exitOK},
+		{"filter", []string{"filter", "--where", `age >= 18 && name startsWith "A"`}, `{"x": {"name": "Ada", "age": 36}, "y": {"name": "Bob", "age": 17}}`, `{"x":{"age":36,"name":"Ada"}}`, exitOK},
+		{"flatten", []string{"flatten", files[0]}, "", `{"a":1,"b.c":2,"d":"x"}`, exitOK},
+		{"intersect", []string{"intersect", files[0], files[1]}, "", `{"a":1}`, exitOK},
+		{"invert", []string{"invert"}, `{"a": "x", "b": 2, "c": null, "d": false}`, `{"2":"b","false":"d","null":"c","x":"a"}`, exitOK},
+		{"keys", []string{"keys", files[1]}, "", `["a","b","e"]`, exitOK},
+		{"merge", []string{"merge", files[0], files[1]}, "", `{"a":1,"b":{"c":3},"d":"x","e":true}`, exitOK},
+		{"merge deep", []string{"merge", "--deep", files[0], "-"}, `{"b": {"f": 4}}`, `{"a":1,"b":{"c":2,"f":4},"d":"x"}`, exitOK},
+		{"values", []string{"values"}, `{"b": 2, "a": 1}`, `[1,2]`, exitOK},
+		{"large integers", []string{"merge", "-", files[1]}, `{"id": 9007199254740993, "ratio": 1.10}`, `{"a":1,"b":{"c":3},"e":true,"id":9007199254740993,"ratio":1.10}`, exitOK},
+		{"number values", []string{"diff", files[1], "-"}, `{"a": 1.0, "b": {"c": 3e0}, "e": true}`, `{"added":{},"changed":{},"removed":{}}`, exitOK},
+		{"large number values", []string{"diff", "-", files[1]}, `{"a": 9007199254740993, "b": {"c": 3}, "e": true}`, `{"added":{},"changed":{"a":{"from":9007199254740993,"to":1}},"removed":{}}`, exitDifferences},
+		{"intersect number values", []string{"intersect", "-", files[1]}, `{"a": 1.00, "b": {"c": 3.0}, "e": false}`, `{"a":1.00,"b":{"c":3.0}}`, exitOK},
+		{"yaml", []string{"merge", "--deep", files[0], "-"}, "# overrides\nb:\n  c: 4\n  f: [x, 'y']\n", `{"a":1,"b":{"c":4,"f":["x","y"]},"d":"x"}`, exitOK},
+		{"yaml number values", []string{"diff", files[1], "-"}, "a: 1.0\nb: {c: 3}\ne: true\n", `{"added":{},"changed":{},"removed":{}}`, exitOK},
+		{"invert numbers", []string{"invert"}, `{"a": 9007199254740993, "b": 1.5}`, `{"1.5":"b","9007199254740993":"a"}`, exitOK},
+	}
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		status := run(test.args, strings.NewReader(test.stdin), &stdout, &stderr)
+		if status != test.status {
+			t.Errorf("%s: expected status %d, but got %d (%s)", test.name, test.status, status, stderr.String())
+			continue
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, stdout.Bytes()); err != nil {
+			t.Errorf("%s: expected JSON output, but got %q", test.name, stdout.String())
+		} else if compact.String() != test.expected {
+			t.Errorf("%s: expected %s, but got %s", test.name, test.expected, compact.String())
+		}
+	}
+}
+
+// TestRunErrors tests that errors exit with status 2 and a message.
+func TestRunErrors(t *testing.T) {
+	files := writeFiles(t, `{"a": {"b": 1}, "c": 1}`, `[1, 2]`)
+	tests := []struct {
+		name  string
+		args  []string
+		stdin string
+	}{
+		{"no command", nil, ""},
+		{"unknown command", []string{"bogus"}, ""},
+		{"unknown flag", []string{"keys", "--bogus"}, ""},
+		{"missing file", []string{"keys", filepath.Join(t.TempDir(), "missing.json")}, ""},
+		{"not an object", []string{"keys", files[1]}, ""},
+		{"invalid JSON", []string{"keys"}, `{"a":`},
+		{"diff arguments", []string{"diff", files[0]}, ""},
+		{"filter without where", []string{"filter", files[0]}, ""},
+		{"filter syntax", []string{"filter", "--where", "age >=", files[0]}, ""},
+		{"invert object", []string{"invert", files[0]}, ""},
+		{"invert duplicate", []string{"invert"}, `{"a": 1, "b": 1}`},
+		{"merge conflict", []string{"merge", "--deep", files[0], "-"}, `{"a": 1}`},
+		{"too many files", []string{"keys", files[0], files[0]}, ""},
+		{"trailing object", []string{"keys"}, `{"a": 1} {"b": 2}`},
+		{"trailing data", []string{"keys"}, `{"a": 1} x`},
+		{"trailing delimiter", []string{"keys"}, `{"a": 1}}`},
+		{"invalid YAML", []string{"keys"}, "a: 1\n  b: 2\n"},
+		{"repeated stdin", []string{"merge", "-", "-"}, `{"a": 1} {"b": 2}`},
+	}
+	for _, test := range tests {
+		var stdout, stderr bytes.Buffer
+		if status := run(test.args, strings.NewReader(test.stdin), &stdout, &stderr); status != exitError {
+			t.Errorf("%s: expected status %d, but got %d", test.name, exitError, status)
+		}
+		if stderr.Len() == 0 {
+			t.Errorf("%s: expected an error message, but got none", test.name)
+		}
+	}
+}
+
+// TestSortedKeys tests sortedKeys.
+func TestSortedKeys(t *testing.T) {
+	if keys := sortedKeys(map[string]any{"b": 1, "c": 2, "a": 3}); !reflect.DeepEqual(keys, []string{"a", "b", "c"}) {
+		t.Errorf("Expected [a b c], but got %v", keys)
+	}
+}
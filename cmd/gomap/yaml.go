@@ -0,0 +1,591 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// errUnsupportedYAML is returned for YAML features outside the subset read by decodeYAML.
+var errUnsupportedYAML = errors.New("unsupported YAML")
+
+// yamlParser reads the subset of YAML used by configuration files: block mappings and sequences, flow mappings
+// and sequences on a single line, plain, single-quoted and double-quoted scalars, literal and folded block scalars,
+// and comments. Anchors, aliases, tags, complex keys, multi-line plain or quoted scalars and multiple documents
+// are reported as errors rather than read differently from other YAML parsers.
+type yamlParser struct {
+	lines []string
+	pos   int
+}
+
+// decodeYAML decodes a YAML document whose root is a mapping. Scalars are resolved using the YAML 1.2 core schema:
+// null, booleans and numbers are decoded as nil, bool and json.Number, and everything else as a string.
+func decodeYAML(data []byte) (map[string]any, error) {
+	text := strings.TrimSuffix(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	parser := &yamlParser{lines: strings.Split(text, "\n")}
+	if err := parser.start(); err != nil {
+		return nil, err
+	}
+	indent, _, ok, err := parser.peek()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("expected a YAML mapping")
+	}
+	value, err := parser.parseNode(indent)
+	if err != nil {
+		return nil, err
+	}
+	if err := parser.end(); err != nil {
+		return nil, err
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.New("expected a YAML mapping")
+	}
+	return object, nil
+}
+
+// start skips directives and the document start marker.
+func (parser *yamlParser) start() error {
+	for ; parser.pos < len(parser.lines); parser.pos++ {
+		line := strings.TrimRight(parser.lines[parser.pos], " \t")
+		switch {
+		case isBlankYAML(line), strings.HasPrefix(line, "%"):
+		case line == "---":
+			parser.pos++
+			return nil
+		case strings.HasPrefix(line, "--- "):
+			return parser.errorf("%w: content after the document start marker", errUnsupportedYAML)
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// end checks that nothing but the document end marker and comments follows the document.
+func (parser *yamlParser) end() error {
+	for ; parser.pos < len(parser.lines); parser.pos++ {
+		line := strings.TrimRight(parser.lines[parser.pos], " \t")
+		switch {
+		case isBlankYAML(line), line == "...":
+		case line == "---" || strings.HasPrefix(line, "--- "):
+			return parser.errorf("%w: multiple documents", errUnsupportedYAML)
+		default:
+			return parser.errorf("unexpected content after the document")
+		}
+	}
+	return nil
+}
+
+// peek returns the indentation and content of the next line that is not blank or a comment, skipping the lines
+// before it. ok is false at the end of the document.
+func (parser *yamlParser) peek() (indent int, content string, ok bool, err error) {
+	for ; parser.pos < len(parser.lines); parser.pos++ {
+		line := strings.TrimRight(parser.lines[parser.pos], " \t")
+		if isBlankYAML(line) {
+			continue
+		}
+		if line == "---" || line == "..." || strings.HasPrefix(line, "--- ") {
+			return 0, "", false, nil
+		}
+		content = strings.TrimLeft(line, " ")
+		if strings.HasPrefix(content, "\t") {
+			return 0, "", false, parser.errorf("tabs cannot be used for indentation")
+		}
+		return len(line) - len(content), content, true, nil
+	}
+	return 0, "", false, nil
+}
+
+// parseNode parses the block node starting on the next line, which is indented by the given number of spaces.
+func (parser *yamlParser) parseNode(indent int) (any, error) {
+	_, content, _, err := parser.peek()
+	if err != nil {
+		return nil, err
+	}
+	if isSequenceItem(content) {
+		return parser.parseSequence(indent)
+	}
+	if _, _, ok, err := splitMappingEntry(content); err != nil {
+		return nil, parser.errorf("%w", err)
+	} else if ok {
+		return parser.parseMapping(indent)
+	}
+	parser.pos++
+	value, err := parseInlineYAML(content)
+	if err != nil {
+		return nil, parser.errorf("%w", err)
+	}
+	return value, nil
+}
+
+// parseMapping parses the entries of a block mapping indented by the given number of spaces.
+func (parser *yamlParser) parseMapping(indent int) (map[string]any, error) {
+	mapping := map[string]any{}
+	for {
+		lineIndent, content, ok, err := parser.peek()
+		if err != nil {
+			return nil, err
+		}
+		if !ok || lineIndent < indent {
+			return mapping, nil
+		}
+		if lineIndent > indent {
+			return nil, parser.errorf("unexpected indentation")
+		}
+		key, rest, ok, err := splitMappingEntry(content)
+		if err != nil {
+			return nil, parser.errorf("%w", err)
+		}
+		if !ok {
+			return nil, parser.errorf("expected a mapping entry")
+		}
+		if _, ok := mapping[key]; ok {
+			return nil, parser.errorf("duplicate key %q", key)
+		}
+		value, err := parser.parseValue(indent, rest, true)
+		if err != nil {
+			return nil, err
+		}
+		mapping[key] = value
+	}
+}
+
+// parseSequence parses the items of a block sequence indented by the given number of spaces.
+func (parser *yamlParser) parseSequence(indent int) ([]any, error) {
+	sequence := []any{}
+	for {
+		lineIndent, content, ok, err := parser.peek()
+		if err != nil {
+			return nil, err
+		}
+		if !ok || lineIndent < indent || (lineIndent == indent && !isSequenceItem(content)) {
+			return sequence, nil
+		}
+		if lineIndent > indent {
+			return nil, parser.errorf("unexpected indentation")
+		}
+		item := strings.TrimLeft(content[1:], " ")
+		column := indent + len(content) - len(item)
+		var value any
+		if _, _, isEntry, _ := splitMappingEntry(item); isEntry || isSequenceItem(item) {
+			// Parse a mapping or sequence that starts on the same line as its dash as if it started on the next line.
+			parser.lines[parser.pos] = strings.Repeat(" ", column) + item
+			value, err = parser.parseNode(column)
+		} else {
+			value, err = parser.parseValue(indent, item, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, value)
+	}
+}
+
+// parseValue parses the value that follows a key or dash on the current line, or on the lines below it if rest is empty.
+// Sequences may start at the same indentation as a mapping key, but not as another sequence item.
+func (parser *yamlParser) parseValue(indent int, rest string, sequenceAtIndent bool) (any, error) {
+	parser.pos++
+	if strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">") {
+		return parser.parseBlockScalar(indent, rest)
+	}
+	if stripComment(rest) != "" {
+		value, err := parseInlineYAML(rest)
+		if err != nil {
+			parser.pos--
+			return nil, parser.errorf("%w", err)
+		}
+		return value, nil
+	}
+	lineIndent, content, ok, err := parser.peek()
+	switch {
+	case err != nil:
+		return nil, err
+	case ok && lineIndent > indent:
+		return parser.parseNode(lineIndent)
+	case ok && lineIndent == indent && sequenceAtIndent && isSequenceItem(content):
+		return parser.parseSequence(indent)
+	}
+	return nil, nil
+}
+
+// parseBlockScalar parses a literal (|) or folded (>) block scalar whose header is on the previous line.
+func (parser *yamlParser) parseBlockScalar(indent int, header string) (string, error) {
+	folded, chomping, blockIndent := header[0] == '>', byte(0), 0
+	for _, c := range []byte(stripComment(header[1:])) {
+		switch {
+		case (c == '-' || c == '+') && chomping == 0:
+			chomping = c
+		case c >= '1' && c <= '9' && blockIndent == 0:
+			blockIndent = indent + int(c-'0')
+		default:
+			parser.pos--
+			return "", parser.errorf("invalid block scalar header %q", header)
+		}
+	}
+	var lines []string
+	for ; parser.pos < len(parser.lines); parser.pos++ {
+		line := strings.TrimRight(parser.lines[parser.pos], " \t")
+		lineIndent := len(line) - len(strings.TrimLeft(line, " "))
+		if line == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if blockIndent == 0 {
+			blockIndent = lineIndent
+		}
+		if lineIndent < blockIndent || lineIndent <= indent {
+			break
+		}
+		lines = append(lines, line[blockIndent:])
+	}
+	trailing := 0
+	for trailing < len(lines) && lines[len(lines)-1-trailing] == "" {
+		trailing++
+	}
+	lines = lines[:len(lines)-trailing]
+	var text string
+	if folded {
+		text = foldLines(lines)
+	} else {
+		text = strings.Join(lines, "\n")
+	}
+	switch {
+	case len(lines) == 0 && chomping != '+':
+		return "", nil
+	case chomping == '-':
+		return text, nil
+	case chomping == '+':
+		return text + strings.Repeat("\n", trailing+1), nil
+	}
+	return text + "\n", nil
+}
+
+// foldLines joins the lines of a folded block scalar. A line break between two lines becomes a space,
+// each empty line becomes a line break, and the line breaks around more indented lines are kept.
+func foldLines(lines []string) string {
+	var builder strings.Builder
+	indented := false
+	for i, line := range lines {
+		if i > 0 {
+			switch {
+			case line == "" || strings.HasPrefix(line, " ") || indented:
+				builder.WriteByte('\n')
+			case lines[i-1] != "":
+				builder.WriteByte(' ')
+			}
+		}
+		if line != "" {
+			indented = strings.HasPrefix(line, " ")
+		}
+		builder.WriteString(line)
+	}
+	return builder.String()
+}
+
+// errorf returns an error that names the current line.
+func (parser *yamlParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("line %d: %w", parser.pos+1, fmt.Errorf(format, args...))
+}
+
+// isBlankYAML checks if the line is empty or holds only a comment.
+func isBlankYAML(line string) bool {
+	trimmed := strings.TrimLeft(line, " \t")
+	return trimmed == "" || strings.HasPrefix(trimmed, "#")
+}
+
+// isSequenceItem checks if the content of a line starts a block sequence item.
+func isSequenceItem(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ")
+}
+
+// splitMappingEntry splits the content of a line into the key and value of a block mapping entry.
+// ok is false if the line is not a mapping entry.
+func splitMappingEntry(content string) (key string, rest string, ok bool, err error) {
+	if content == "" || strings.ContainsRune("[{#|>", rune(content[0])) {
+		return "", "", false, nil
+	}
+	if strings.HasPrefix(content, "? ") || content == "?" {
+		return "", "", false, fmt.Errorf("%w: complex keys", errUnsupportedYAML)
+	}
+	if content[0] == '"' || content[0] == '\'' {
+		key, n, err := parseQuoted(content)
+		if err != nil {
+			return "", "", false, err
+		}
+		after := strings.TrimLeft(content[n:], " ")
+		if after != ":" && !strings.HasPrefix(after, ": ") {
+			return "", "", false, nil
+		}
+		return key, strings.TrimSpace(after[1:]), true, nil
+	}
+	for i := 0; i < len(content); i++ {
+		if content[i] == '#' && i > 0 && content[i-1] == ' ' {
+			return "", "", false, nil
+		}
+		if content[i] == ':' && (i == len(content)-1 || content[i+1] == ' ') {
+			key := strings.TrimRight(content[:i], " ")
+			if strings.HasPrefix(key, "- ") || key == "-" {
+				return "", "", false, nil
+			}
+			if err := checkPlainStart(key); err != nil {
+				return "", "", false, err
+			}
+			return key, strings.TrimSpace(content[i+1:]), true, nil
+		}
+	}
+	return "", "", false, nil
+}
+
+// stripComment removes a trailing comment from the unquoted text and trims it.
+func stripComment(text string) string {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "#") {
+		return ""
+	}
+	if i := strings.Index(text, " #"); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text)
+}
+
+// parseInlineYAML parses a flow collection or a scalar that is written on a single line, followed by an optional comment.
+func parseInlineYAML(text string) (any, error) {
+	text = strings.TrimSpace(text)
+	if text != "" && (text[0] == '[' || text[0] == '{' || text[0] == '"' || text[0] == '\'') {
+		flow := &flowParser{text: text}
+		value, err := flow.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		if rest := strings.TrimSpace(flow.text[flow.pos:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return nil, fmt.Errorf("unexpected %q after value", rest)
+		}
+		return value, nil
+	}
+	plain := stripComment(text)
+	if err := checkPlainStart(plain); err != nil {
+		return nil, err
+	}
+	return resolveScalar(plain)
+}
+
+// checkPlainStart reports plain scalars that start with an indicator of an unsupported feature.
+func checkPlainStart(plain string) error {
+	if plain == "" {
+		return nil
+	}
+	switch plain[0] {
+	case '&':
+		return fmt.Errorf("%w: anchors", errUnsupportedYAML)
+	case '*':
+		return fmt.Errorf("%w: aliases", errUnsupportedYAML)
+	case '!':
+		return fmt.Errorf("%w: tags", errUnsupportedYAML)
+	case '@', '`', '%':
+		return fmt.Errorf("plain scalars cannot start with %q", plain[0])
+	}
+	return nil
+}
+
+// resolveScalar resolves a plain scalar to null, a boolean, a number or a string.
+func resolveScalar(plain string) (any, error) {
+	switch plain {
+	case "", "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	case ".inf", ".Inf", ".INF", "+.inf", "+.Inf", "+.INF", "-.inf", "-.Inf", "-.INF", ".nan", ".NaN", ".NAN":
+		return nil, fmt.Errorf("%s cannot be represented in JSON", plain)
+	}
+	if number, ok := resolveNumber(plain); ok {
+		return number, nil
+	}
+	return plain, nil
+}
+
+// resolveNumber converts a plain scalar that is a YAML integer or float to a json.Number. Decimal numbers keep
+// their text where it is valid JSON, so that large integers are not rounded.
+func resolveNumber(plain string) (json.Number, bool) {
+	unsigned := strings.TrimPrefix(plain, "+")
+	if unsigned == "" || strings.HasPrefix(unsigned, "+") || plain != unsigned && unsigned[0] == '-' {
+		return "", false
+	}
+	if strings.TrimLeft(unsigned, "0123456789.eE+-") != "" {
+		if strings.HasPrefix(unsigned, "0x") || strings.HasPrefix(unsigned, "0o") {
+			if n, err := strconv.ParseInt(unsigned, 0, 64); err == nil {
+				return json.Number(strconv.FormatInt(n, 10)), true
+			}
+		}
+		return "", false
+	}
+	if json.Valid([]byte(unsigned)) {
+		return json.Number(unsigned), true
+	}
+	n, err := strconv.ParseFloat(unsigned, 64)
+	if err != nil || math.IsInf(n, 0) {
+		return "", false
+	}
+	return json.Number(strconv.FormatFloat(n, 'g', -1, 64)), true
+}
+
+// parseQuoted parses the single-quoted or double-quoted scalar at the start of the text and returns its value
+// and the number of bytes it spans.
+func parseQuoted(text string) (string, int, error) {
+	quote := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '\'' && text[i] == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case quote == '"' && text[i] == '\\':
+			i++
+		case text[i] == quote:
+			if quote == '\'' {
+				return strings.ReplaceAll(text[1:i], "''", "'"), i + 1, nil
+			}
+			value, err := strconv.Unquote(text[:i+1])
+			if err != nil {
+				return "", 0, fmt.Errorf("invalid double-quoted scalar %s", text[:i+1])
+			}
+			return value, i + 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("%w: unterminated or multi-line quoted scalar", errUnsupportedYAML)
+}
+
+// flowParser parses a flow collection or quoted scalar written on a single line.
+type flowParser struct {
+	pos  int
+	text string
+}
+
+// parseValue parses a flow sequence, flow mapping or scalar.
+func (flow *flowParser) parseValue() (any, error) {
+	flow.skipSpaces()
+	if flow.pos == len(flow.text) {
+		return nil, fmt.Errorf("%w: multi-line flow collection", errUnsupportedYAML)
+	}
+	switch flow.text[flow.pos] {
+	case '[':
+		return flow.parseSequence()
+	case '{':
+		return flow.parseMapping()
+	case '"', '\'':
+		value, n, err := parseQuoted(flow.text[flow.pos:])
+		flow.pos += n
+		return value, err
+	}
+	start := flow.pos
+	for flow.pos < len(flow.text) && !strings.ContainsRune(",]}", rune(flow.text[flow.pos])) &&
+		!(flow.text[flow.pos] == ':' && (flow.pos+1 == len(flow.text) || strings.ContainsRune(" ,]}", rune(flow.text[flow.pos+1])))) &&
+		!(flow.text[flow.pos] == '#' && flow.text[flow.pos-1] == ' ') {
+		flow.pos++
+	}
+	plain := strings.TrimSpace(flow.text[start:flow.pos])
+	if err := checkPlainStart(plain); err != nil {
+		return nil, err
+	}
+	return resolveScalar(plain)
+}
+
+// parseSequence parses a flow sequence such as [a, b].
+func (flow *flowParser) parseSequence() ([]any, error) {
+	flow.pos++
+	sequence := []any{}
+	for {
+		flow.skipSpaces()
+		if flow.consume(']') {
+			return sequence, nil
+		}
+		value, err := flow.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		sequence = append(sequence, value)
+		if err := flow.separator(']'); err != nil {
+			return nil, err
+		}
+		if flow.consume(']') {
+			return sequence, nil
+		}
+	}
+}
+
+// parseMapping parses a flow mapping such as {a: 1, b: 2}.
+func (flow *flowParser) parseMapping() (map[string]any, error) {
+	flow.pos++
+	mapping := map[string]any{}
+	for {
+		flow.skipSpaces()
+		if flow.consume('}') {
+			return mapping, nil
+		}
+		key, err := flow.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		flow.skipSpaces()
+		var value any
+		if flow.consume(':') {
+			if value, err = flow.parseValue(); err != nil {
+				return nil, err
+			}
+		}
+		name, ok := key.(string)
+		if !ok {
+			name = fmt.Sprint(key)
+			if key == nil {
+				name = "null"
+			}
+		}
+		if _, ok := mapping[name]; ok {
+			return nil, fmt.Errorf("duplicate key %q", name)
+		}
+		mapping[name] = value
+		if err := flow.separator('}'); err != nil {
+			return nil, err
+		}
+		if flow.consume('}') {
+			return mapping, nil
+		}
+	}
+}
+
+// separator consumes the comma between two entries, or checks that the collection is closed.
+func (flow *flowParser) separator(closing byte) error {
+	flow.skipSpaces()
+	if flow.consume(',') {
+		return nil
+	}
+	if flow.pos < len(flow.text) && flow.text[flow.pos] == closing {
+		return nil
+	}
+	if flow.pos == len(flow.text) {
+		return fmt.Errorf("%w: multi-line flow collection", errUnsupportedYAML)
+	}
+	return fmt.Errorf("expected ',' or '%c', but got %q", closing, flow.text[flow.pos:])
+}
+
+// consume advances past the byte if it is next.
+func (flow *flowParser) consume(c byte) bool {
+	if flow.pos < len(flow.text) && flow.text[flow.pos] == c {
+		flow.pos++
+		return true
+	}
+	return false
+}
+
+// skipSpaces advances past spaces.
+func (flow *flowParser) skipSpaces() {
+	for flow.pos < len(flow.text) && flow.text[flow.pos] == ' ' {
+		flow.pos++
+	}
+}
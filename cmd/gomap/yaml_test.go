@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// TestDecodeYAML tests decodeYAML by comparing its result, encoded as JSON, with the expected JSON.
+func TestDecodeYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		expected string
+	}{
+		{"scalars", "a: 1\nb: 1.50\nc: true\nd: ~\ne: hello world\nf: 'it''s'\ng: \"tab\\t\"\nh:\ni: 0x1F\nj: +5\nk: 9007199254740993\n",
+			`{"a":1,"b":1.50,"c":true,"d":null,"e":"hello world","f":"it's","g":"tab\t","h":null,"i":31,"j":5,"k":9007199254740993}`},
+		{"comments", "# config\n---\na: 1 # one\nb: 'x # y' # z\nc: x#y\n...\n", `{"a":1,"b":"x # y","c":"x#y"}`},
+		{"nested", "server:\n  host: localhost\n  ports:\n    - 80\n    - 443\n  tls:\n    enabled: false\n", `{"server":{"host":"localhost","ports":[80,443],"tls":{"enabled":false}}}`},
+		{"sequence at key indentation", "tags:\n- a\n- b\nname: x\n", `{"name":"x","tags":["a","b"]}`},
+		{"sequence of mappings", "users:\n  - name: ada\n    age: 36\n  - name: bob\n    roles:\n    - admin\n  -\n    name: eve\n", `{"users":[{"age":36,"name":"ada"},{"name":"bob","roles":["admin"]},{"name":"eve"}]}`},
+		{"nested sequences", "matrix:\n  - - 1\n    - 2\n  - [3, 4]\n", `{"matrix":[[1,2],[3,4]]}`},
+		{"flow", "a: [x, 'y, z', {b: 1, \"c d\": [true, null]}]\ne: {}\nf: [] # empty\n", `{"a":["x","y, z",{"b":1,"c d":[true,null]}],"e":{},"f":[]}`},
+		{"literal", "script: |\n  echo a\n    echo b\n\n  echo c\nnext: 1\n", `{"next":1,"script":"echo a\n  echo b\n\necho c\n"}`},
+		{"folded", "text: >-\n  one\n  two\n\n  three\n", `{"text":"one two\nthree"}`},
+		{"folded indented", "text: >\n  a\n    b\n\n  c\n", `{"text":"a\n  b\n\nc\n"}`},
+		{"keep", "text: |+\n  one\n\n", `{"text":"one\n\n"}`},
+		{"quoted keys", "\"a: b\": 1\n'c': 2\n3: three\n", `{"3":"three","a: b":1,"c":2}`},
+		{"json", "{\"a\": [1, 2], \"b\": \"c\"}\n", `{"a":[1,2],"b":"c"}`},
+	}
+	for _, test := range tests {
+		object, err := decodeYAML([]byte(test.document))
+		if err != nil {
+			t.Errorf("%s: expected no error, but got %v", test.name, err)
+			continue
+		}
+		if data, _ := json.Marshal(object); string(data) != test.expected {
+			t.Errorf("%s: expected %s, but got %s", test.name, test.expected, data)
+		}
+	}
+}
+
+// TestDecodeYAMLErrors tests that decodeYAML rejects invalid documents and unsupported features.
+func TestDecodeYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		document    string
+		unsupported bool
+	}{
+		{"empty", "", false},
+		{"not a mapping", "- a\n- b\n", false},
+		{"scalar", "hello\n", false},
+		{"indentation", "a: 1\n  b: 2\n", false},
+		{"tabs", "a:\n\tb: 1\n", false},
+		{"duplicate key", "a: 1\na: 2\n", false},
+		{"not an entry", "a: 1\nb\n", false},
+		{"unterminated flow", "a: [1, 2\n", true},
+		{"unterminated quote", "a: 'b\n", true},
+		{"infinity", "a: .inf\n", false},
+		{"anchor", "a: &x 1\n", true},
+		{"alias", "a: *x\n", true},
+		{"tag", "a: !!str 1\n", true},
+		{"complex key", "? a\n: 1\n", true},
+		{"multiple documents", "a: 1\n---\nb: 2\n", true},
+	}
+	for _, test := range tests {
+		_, err := decodeYAML([]byte(test.document))
+		if err == nil {
+			t.Errorf("%s: expected an error, but got none", test.name)
+		} else if errors.Is(err, errUnsupportedYAML) != test.unsupported {
+			t.Errorf("%s: expected an unsupported YAML error to be %t, but got %v", test.name, test.unsupported, err)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package gomap
+
+import (
+	"container/heap"
+
+	"github.com/lindsaygelle/slice"
+)
+
+// Counter counts occurrences of keys of type K.
+// Missing keys have a count of zero. Counts may become zero or negative through Dec and Subtract;
+// use DeleteNonPositive to remove them.
+type Counter[K comparable] map[K]int
+
+// NewCounter creates a new Counter and counts each of the provided keys once.
+//
+//	// Create a new Counter instance.
+//	counter := gomap.NewCounter("apple", "banana", "apple")
+//	count := counter.Get("apple") // 2
+func NewCounter[K comparable](keys ...K) *Counter[K] {
+	counter := make(Counter[K])
+	for _, key := range keys {
+		counter.Inc(key)
+	}
+	return &counter
+}
+
+// Add adds the counts of another Counter to the current Counter.
+//
+//	// Create two new Counter instances.
+//	counter1 := gomap.NewCounter("apple", "apple")
+//	counter2 := gomap.NewCounter("apple", "banana")
+//	counter1.Add(counter2) // {"apple": 3, "banana": 1}
+func (counter *Counter[K]) Add(other *Counter[K]) *Counter[K] {
+	for key, count := range *other {
+		counter.IncBy(key, count)
+	}
+	return counter
+}
+
+// Dec decrements the count of the key by one and returns the new count.
+//
+//	// Create a new Counter instance.
+//	counter := gomap.NewCounter("apple", "apple")
+//	count := counter.Dec("apple") // 1
+func (counter *Counter[K]) Dec(key K) int {
+	return counter.IncBy(key, -1)
+}
+
+// DeleteNonPositive removes all keys with a count of zero or less.
+//
+//	// Create a new Counter instance.
+//	counter := gomap.NewCounter("apple")
+//	counter.Dec("apple")
+//	counter.DeleteNonPositive() // {}
+func (counter *Counter[K]) DeleteNonPositive() *Counter[K] {
+	for key, count := range *counter {
+		if count <= 0 {
+			delete(*counter, key)
+		}
+	}
+	return counter
+}
+
+// Get returns the count of the key, or zero if the key has not been counted.
+//
+//	// Create a new Counter instance.
+//	counter := gomap.NewCounter("apple")
+//	count := counter.Get("apple")  // 1
+//	count = counter.Get("banana") // 0
+func (counter *Counter[K]) Get(key K) int {
+	return (*counter)[key]
+}
+
+// Inc increments the count of the key by one and returns the new count.
+//
+//	// Create a new Counter instance.
+//	counter := gomap.NewCounter[string]()
+//	count := counter.Inc("apple") // 1
+func (counter *Counter[K]) Inc(key K) int {
+	return counter.IncBy(key, 1)
+}
+
+// IncBy increments the count of the key by n and returns the new count.
+//
+//	// Create a new Counter instance.
+//	counter := gomap.NewCounter[string]()
+//	count := counter.IncBy("apple", 5) // 5
+func (counter *Counter[K]) IncBy(key K, n int) int {
+	(*counter)[key] += n
+	return (*counter)[key]
+}
+
+// Intersect sets the count of each key to the minimum of its counts in both Counters.
+// Keys that are not present in the other Counter are removed.
+//
+//	// Create two new Counter instances.
+//	counter1 := gomap.NewCounter("apple", "apple", "banana")
+//	counter2 := gomap.NewCounter("apple", "cherry")
+//	counter1.Intersect(counter2) // {"apple": 1}
+func (counter *Counter[K]) Intersect(other *Counter[K]) *Counter[K] {
+	for key, count := range *counter {
+		otherCount, ok := (*other)[key]
+		if !ok {
+			delete(*counter, key)
+		} else if otherCount < count {
+			(*counter)[key] = otherCount
+		}
+	}
+	return counter
+}
+
+// Keys returns a slice containing all the counted keys.
+func (counter *Counter[K]) Keys() *slice.Slice[K] {
+	keys := make(slice.Slice[K], 0, counter.Length())
+	for key := range *counter {
+		keys.Append(key)
+	}
+	return &keys
+}
+
+// LeastCommon returns the n keys with the lowest counts, ordered from least to most common.
+// If n is negative or greater than the number of keys, all keys are returned. The order of keys with equal counts is unspecified.
+//
+//	// Create a new Counter instance.
+//	counter := gomap.NewCounter("apple", "apple", "banana")
+//	entries := counter.LeastCommon(1) // {{"banana", 1}}
+func (counter *Counter[K]) LeastCommon(n int) *slice.Slice[Entry[K, int]] {
+	return counter.top(n, func(a, b int) bool {
+		return a > b
+	})
+}
+
+// Length returns the number of counted keys.
+func (counter *Counter[K]) Length() int {
+	return len(*counter)
+}
+
+// MostCommon returns the n keys with the highest counts, ordered from most to least common.
+// If n is negative or greater than the number of keys, all keys are returned. The order of keys with equal counts is unspecified.
+// Only n entries are kept in a heap while scanning, so the Counter is not sorted as a whole.
+//
+//	// Create a new Counter instance.
+//	counter := gomap.NewCounter("apple", "apple", "banana")
+//	entries := counter.MostCommon(1) // {{"apple", 2}}
+func (counter *Counter[K]) MostCommon(n int) *slice.Slice[Entry[K, int]] {
+	return counter.top(n, func(a, b int) bool {
+		return a < b
+	})
+}
+
+// Subtract subtracts the counts of another Counter from the current Counter. Counts may become zero or negative.
+//
+//	// Create two new Counter instances.
+//	counter1 := gomap.NewCounter("apple", "apple")
+//	counter2 := gomap.NewCounter("apple", "banana")
+//	counter1.Subtract(counter2) // {"apple": 1, "banana": -1}
+func (counter *Counter[K]) Subtract(other *Counter[K]) *Counter[K] {
+	for key, count := range *other {
+		counter.IncBy(key, -count)
+	}
+	return counter
+}
+
+// Total returns the sum of all counts.
+//
+//	// Create a new Counter instance.
+//	counter := gomap.NewCounter("apple", "apple", "banana")
+//	total := counter.Total() // 3
+func (counter *Counter[K]) Total() int {
+	total := 0
+	for _, count := range *counter {
+		total += count
+	}
+	return total
+}
+
+// Union sets the count of each key to the maximum of its counts in both Counters.
+// Missing keys count as zero, so a key missing from the current Counter is only added
+// if its count in the other Counter is positive.
+//
+//	// Create two new Counter instances.
+//	counter1 := gomap.NewCounter("apple", "apple")
+//	counter2 := gomap.NewCounter("apple", "banana")
+//	counter1.Union(counter2) // {"apple": 2, "banana": 1}
+func (counter *Counter[K]) Union(other *Counter[K]) *Counter[K] {
+	for key, count := range *other {
+		if count > counter.Get(key) {
+			(*counter)[key] = count
+		}
+	}
+	return counter
+}
+
+// top returns the n entries that rank highest according to less, ordered from highest to lowest.
+// A heap holding the n best entries seen so far keeps the cost at O(m log n) for m keys.
+func (counter *Counter[K]) top(n int, less func(a, b int) bool) *slice.Slice[Entry[K, int]] {
+	if n < 0 || n > counter.Length() {
+		n = counter.Length()
+	}
+	entries := &entryHeap[K]{entries: make([]Entry[K, int], 0, n), less: less}
+	if n > 0 {
+		for key, count := range *counter {
+			entry := Entry[K, int]{Key: key, Value: count}
+			if entries.Len() < n {
+				heap.Push(entries, entry)
+			} else if less(entries.entries[0].Value, count) {
+				entries.entries[0] = entry
+				heap.Fix(entries, 0)
+			}
+		}
+	}
+	result := make(slice.Slice[Entry[K, int]], entries.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(entries).(Entry[K, int])
+	}
+	return &result
+}
+
+// entryHeap is a heap of counts whose root is the entry that ranks lowest according to less.
+type entryHeap[K comparable] struct {
+	entries []Entry[K, int]
+	less    func(a, b int) bool
+}
+
+func (entryHeap *entryHeap[K]) Len() int {
+	return len(entryHeap.entries)
+}
+
+func (entryHeap *entryHeap[K]) Less(i, j int) bool {
+	return entryHeap.less(entryHeap.entries[i].Value, entryHeap.entries[j].Value)
+}
+
+func (entryHeap *entryHeap[K]) Pop() any {
+	last := len(entryHeap.entries) - 1
+	entry := entryHeap.entries[last]
+	entryHeap.entries = entryHeap.entries[:last]
+	return entry
+}
+
+func (entryHeap *entryHeap[K]) Push(value any) {
+	entryHeap.entries = append(entryHeap.entries, value.(Entry[K, int]))
+}
+
+func (entryHeap *entryHeap[K]) Swap(i, j int) {
+	entryHeap.entries[i], entryHeap.entries[j] = entryHeap.entries[j], entryHeap.entries[i]
+}
@@ -0,0 +1,124 @@
+package gomap_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// TestCounterInc tests Counter.Inc, Counter.IncBy and Counter.Dec.
+func TestCounterInc(t *testing.T) {
+	counter := gomap.NewCounter("apple", "banana", "apple")
+	if count := counter.Get("apple"); count != 2 {
+		t.Errorf("Expected count 2 for 'apple', but got %d", count)
+	}
+	if count := counter.Inc("cherry"); count != 1 {
+		t.Errorf("Expected count 1 for 'cherry', but got %d", count)
+	}
+	if count := counter.IncBy("cherry", 4); count != 5 {
+		t.Errorf("Expected count 5 for 'cherry', but got %d", count)
+	}
+	if count := counter.Dec("banana"); count != 0 {
+		t.Errorf("Expected count 0 for 'banana', but got %d", count)
+	}
+	if total := counter.Total(); total != 7 {
+		t.Errorf("Expected total 7, but got %d", total)
+	}
+	if count := counter.Get("missing"); count != 0 {
+		t.Errorf("Expected count 0 for a missing key, but got %d", count)
+	}
+}
+
+// TestCounterDeleteNonPositive tests Counter.DeleteNonPositive.
+func TestCounterDeleteNonPositive(t *testing.T) {
+	counter := &gomap.Counter[string]{"apple": 2, "banana": 0, "cherry": -1}
+	counter.DeleteNonPositive()
+	if counter.Length() != 1 || counter.Get("apple") != 2 {
+		t.Errorf("Expected only 'apple' to remain, but got %v", *counter)
+	}
+}
+
+// TestCounterArithmetic tests Counter.Add, Counter.Subtract, Counter.Union and Counter.Intersect.
+func TestCounterArithmetic(t *testing.T) {
+	newCounters := func() (*gomap.Counter[string], *gomap.Counter[string]) {
+		return &gomap.Counter[string]{"apple": 3, "banana": 1}, &gomap.Counter[string]{"apple": 1, "cherry": 2}
+	}
+	tests := []struct {
+		name     string
+		fn       func(a, b *gomap.Counter[string]) *gomap.Counter[string]
+		expected gomap.Counter[string]
+	}{
+		{"Add", (*gomap.Counter[string]).Add, gomap.Counter[string]{"apple": 4, "banana": 1, "cherry": 2}},
+		{"Subtract", (*gomap.Counter[string]).Subtract, gomap.Counter[string]{"apple": 2, "banana": 1, "cherry": -2}},
+		{"Union", (*gomap.Counter[string]).Union, gomap.Counter[string]{"apple": 3, "banana": 1, "cherry": 2}},
+		{"Intersect", (*gomap.Counter[string]).Intersect, gomap.Counter[string]{"apple": 1}},
+	}
+	for _, test := range tests {
+		a, b := newCounters()
+		result := test.fn(a, b)
+		if result != a {
+			t.Errorf("%s: expected the receiver to be returned", test.name)
+		}
+		if len(*result) != len(test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, *result)
+			continue
+		}
+		for key, count := range test.expected {
+			if result.Get(key) != count {
+				t.Errorf("%s: expected %v, but got %v", test.name, test.expected, *result)
+				break
+			}
+		}
+	}
+}
+
+// TestCounterUnionNonPositive tests that Counter.Union treats missing keys as having a count of zero.
+func TestCounterUnionNonPositive(t *testing.T) {
+	counter := &gomap.Counter[string]{"apple": -2, "banana": 1}
+	counter.Union(&gomap.Counter[string]{"apple": -1, "banana": -3, "cherry": -4, "date": 0})
+	expected := gomap.Counter[string]{"apple": -1, "banana": 1}
+	if len(*counter) != len(expected) || counter.Get("apple") != -1 || counter.Get("banana") != 1 {
+		t.Errorf("Expected %v, but got %v", expected, *counter)
+	}
+}
+
+// TestCounterMostCommon tests Counter.MostCommon and Counter.LeastCommon.
+func TestCounterMostCommon(t *testing.T) {
+	counter := &gomap.Counter[string]{"apple": 5, "banana": 2, "cherry": 8, "date": 1}
+
+	mostCommon := counter.MostCommon(2)
+	if mostCommon.Length() != 2 || mostCommon.Fetch(0).Key != "cherry" || mostCommon.Fetch(1).Key != "apple" {
+		t.Errorf("Expected [cherry apple], but got %v", mostCommon)
+	}
+	leastCommon := counter.LeastCommon(3)
+	if leastCommon.Length() != 3 || leastCommon.Fetch(0).Key != "date" || leastCommon.Fetch(2).Key != "apple" {
+		t.Errorf("Expected [date banana apple], but got %v", leastCommon)
+	}
+	if all := counter.MostCommon(-1); all.Length() != 4 || all.Fetch(3).Value != 1 {
+		t.Errorf("Expected all entries in descending order, but got %v", all)
+	}
+	if none := counter.MostCommon(0); none.Length() != 0 {
+		t.Errorf("Expected no entries, but got %v", none)
+	}
+}
+
+// TestCounterMostCommonRandom tests Counter.MostCommon against a full sort.
+func TestCounterMostCommonRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	counter := gomap.NewCounter[int]()
+	for i := 0; i < 5000; i++ {
+		counter.Inc(random.Intn(500))
+	}
+	counts := []int{}
+	for _, count := range *counter {
+		counts = append(counts, count)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(counts)))
+	for i, entry := range *counter.MostCommon(25) {
+		if entry.Value != counts[i] || counter.Get(entry.Key) != entry.Value {
+			t.Fatalf("Expected entry %d to have count %d, but got %v", i, counts[i], entry)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package gomap
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// COWMap is a copy-on-write map that is safe for concurrent use.
+// The current contents are held in an ImmutableMap that is published atomically: readers load it without locking,
+// so they never block, and writers derive the next version from it, copying only the O(log n) nodes on the path
+// to the changed key, so versions held by snapshots are never modified. Snapshot returns the published version
+// as an immutable point-in-time View in O(1). The zero value is an empty map ready to use.
+type COWMap[K comparable, V any] struct {
+	immutableMap atomic.Pointer[ImmutableMap[K, V]]
+	mutex        sync.Mutex
+}
+
+// NewCOWMap creates a new COWMap containing the key-value pairs of the provided maps.
+//
+//	// Create a new COWMap instance.
+//	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5})
+//	snapshot := cowMap.Snapshot()
+//	cowMap.Add("banana", 3) // snapshot still only holds "apple"
+func NewCOWMap[K comparable, V any](values ...map[K]V) *COWMap[K, V] {
+	builder := NewImmutableMapBuilder[K, V]()
+	for _, value := range values {
+		for key, value := range value {
+			builder.Set(key, value)
+		}
+	}
+	cowMap := &COWMap[K, V]{}
+	cowMap.immutableMap.Store(builder.Build())
+	return cowMap
+}
+
+// Add inserts a new key-value pair into the map or updates the existing value associated with the provided key in O(log n).
+//
+//	// Create a new COWMap instance.
+//	cowMap := gomap.NewCOWMap[string, int]()
+//	cowMap.Add("apple", 5)
+func (cowMap *COWMap[K, V]) Add(key K, value V) *COWMap[K, V] {
+	cowMap.mutex.Lock()
+	defer cowMap.mutex.Unlock()
+	cowMap.immutableMap.Store(cowMap.load().Set(key, value))
+	return cowMap
+}
+
+// Delete removes the key-value pair with the provided key from the map in O(log n).
+//
+//	// Create a new COWMap instance.
+//	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5})
+//	cowMap.Delete("apple")
+func (cowMap *COWMap[K, V]) Delete(key K) *COWMap[K, V] {
+	cowMap.mutex.Lock()
+	defer cowMap.mutex.Unlock()
+	cowMap.immutableMap.Store(cowMap.load().Delete(key))
+	return cowMap
+}
+
+// Each executes the provided function for each key-value pair of a snapshot of the map.
+// Writers are not blocked while the iteration is in progress and their changes are not observed by it.
+//
+//	// Create a new COWMap instance.
+//	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5})
+//	cowMap.Each(func(key string, value int) {
+//		fmt.Println(key, value) // apple 5
+//	})
+func (cowMap *COWMap[K, V]) Each(fn func(key K, value V)) *COWMap[K, V] {
+	cowMap.load().Each(fn)
+	return cowMap
+}
+
+// Get retrieves the value associated with the provided key and a boolean indicating whether the key exists.
+//
+//	// Create a new COWMap instance.
+//	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5})
+//	value, ok := cowMap.Get("apple") // 5, true
+func (cowMap *COWMap[K, V]) Get(key K) (V, bool) {
+	return cowMap.load().Get(key)
+}
+
+// Has checks if the provided key exists in the map.
+//
+//	// Create a new COWMap instance.
+//	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5})
+//	ok := cowMap.Has("apple") // true
+func (cowMap *COWMap[K, V]) Has(key K) bool {
+	return cowMap.load().Has(key)
+}
+
+// Length returns the number of key-value pairs in the map.
+//
+//	// Create a new COWMap instance.
+//	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5})
+//	length := cowMap.Length() // 1
+func (cowMap *COWMap[K, V]) Length() int {
+	return cowMap.load().Length()
+}
+
+// load returns the published version of the map, or an empty map if nothing has been published yet.
+func (cowMap *COWMap[K, V]) load() *ImmutableMap[K, V] {
+	if immutableMap := cowMap.immutableMap.Load(); immutableMap != nil {
+		return immutableMap
+	}
+	return &ImmutableMap[K, V]{}
+}
+
+// Snapshot returns an immutable point-in-time View of the map in O(1).
+// The View can be read concurrently without locking and is unaffected by later writes.
+//
+//	// Create a new COWMap instance.
+//	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5})
+//	snapshot := cowMap.Snapshot()
+//	cowMap.Add("apple", 10)
+//	value := snapshot.Fetch("apple") // 5
+func (cowMap *COWMap[K, V]) Snapshot() View[K, V] {
+	return &immutableView[K, V]{immutableMap: cowMap.load()}
+}
+
+// Update calls the provided function with a Map holding the contents of the map, and publishes its changes
+// when the function returns. Updates are serialized with each other but do not block readers, which observe
+// either all or none of the changes made by the function. Building the Map costs O(n), so use Add and Delete
+// for individual writes. The function must not retain the map after it returns.
+//
+//	// Create a new COWMap instance.
+//	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5})
+//	cowMap.Update(func(gomap *gomap.Map[string, int]) {
+//		gomap.ReplaceMany(func(key string, value int) (int, bool) {
+//			return value * 2, true
+//		})
+//	})
+func (cowMap *COWMap[K, V]) Update(fn func(gomap *Map[K, V])) *COWMap[K, V] {
+	cowMap.mutex.Lock()
+	defer cowMap.mutex.Unlock()
+	current := cowMap.load()
+	gomap := current.Map()
+	fn(gomap)
+	builder := current.Builder()
+	current.Each(func(key K, value V) {
+		if gomap.Not(key) {
+			builder.Delete(key)
+		}
+	})
+	gomap.Each(func(key K, value V) {
+		builder.Set(key, value)
+	})
+	cowMap.immutableMap.Store(builder.Build())
+	return cowMap
+}
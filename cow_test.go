@@ -0,0 +1,152 @@
+package gomap_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// TestCOWMapSnapshot tests COWMap.Snapshot.
+func TestCOWMapSnapshot(t *testing.T) {
+	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5})
+	snapshot := cowMap.Snapshot()
+
+	cowMap.Add("apple", 10).Add("banana", 3).Delete("cherry")
+	if snapshot.Length() != 1 || snapshot.Fetch("apple") != 5 {
+		t.Errorf("Expected snapshot to be unaffected by later writes, but got length %d", snapshot.Length())
+	}
+	if value, ok := cowMap.Get("apple"); !ok || value != 10 {
+		t.Errorf("Expected key 'apple' to have value 10, but got %v", value)
+	}
+	if cowMap.Length() != 2 || !cowMap.Has("banana") {
+		t.Errorf("Expected map to contain the written pairs")
+	}
+
+	// A second snapshot observes the writes made since the first.
+	if !cowMap.Snapshot().Equal(&gomap.Map[string, int]{"apple": 10, "banana": 3}) {
+		t.Errorf("Expected new snapshot to contain the latest writes")
+	}
+}
+
+// TestCOWMapUpdate tests COWMap.Update.
+func TestCOWMapUpdate(t *testing.T) {
+	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5, "banana": 3})
+	snapshot := cowMap.Snapshot()
+	cowMap.Update(func(newMap *gomap.Map[string, int]) {
+		newMap.ReplaceMany(func(key string, value int) (int, bool) {
+			return value * 2, true
+		})
+	})
+	if !snapshot.Equal(&gomap.Map[string, int]{"apple": 5, "banana": 3}) {
+		t.Errorf("Expected snapshot to be unaffected by Update")
+	}
+	if cowMap.Snapshot().Fetch("apple") != 10 {
+		t.Errorf("Expected Update to double the value of 'apple'")
+	}
+}
+
+// TestCOWMapConcurrent tests COWMap with concurrent writers and snapshot readers. Run with -race.
+func TestCOWMapConcurrent(t *testing.T) {
+	const writers, writes = 4, 500
+	cowMap := gomap.NewCOWMap[int, int]()
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < writes; i++ {
+				cowMap.Add(w*writes+i, i)
+				if i > 0 && i%3 == 0 {
+					cowMap.Delete(w*writes + i - 1)
+				}
+			}
+		}(w)
+	}
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				snapshot := cowMap.Snapshot()
+				length := snapshot.Length()
+				count := 0
+				cowMap.Has(i)
+				snapshot.Each(func(key int, value int) {
+					count++
+				})
+				if count != length || snapshot.Length() != length {
+					t.Errorf("Expected snapshot to stay consistent, but counted %d of %d", count, length)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	expected := writers * (writes - (writes-1)/3)
+	if cowMap.Length() != expected {
+		t.Errorf("Expected length %d, but got %d", expected, cowMap.Length())
+	}
+}
+
+// TestCOWMapSnapshotView tests that the View returned by COWMap.Snapshot matches a Map with the same contents.
+func TestCOWMapSnapshotView(t *testing.T) {
+	values := map[string]int{"apple": 5, "banana": 3, "cherry": 8}
+	snapshot := gomap.NewCOWMap[string, int](values).Snapshot()
+	expected := gomap.Map[string, int](values)
+	if !snapshot.Equal(&expected) || !snapshot.EqualLength(&expected) || snapshot.Immutable().Length() != 3 {
+		t.Errorf("Expected the snapshot to equal %v", values)
+	}
+	if snapshot.Fetch("apple") != 5 || !snapshot.Has("banana") || snapshot.Not("banana") || !snapshot.Not("durian") {
+		t.Errorf("Expected lookups to match the map")
+	}
+	if key, ok := snapshot.Contains(8); !ok || key != "cherry" {
+		t.Errorf("Expected cherry, true, but got %q, %t", key, ok)
+	}
+	if filtered := snapshot.Filter(func(key string, value int) bool { return value > 4 }); filtered.Length() != 2 {
+		t.Errorf("Expected 2 values greater than 4, but got %v", filtered)
+	}
+	sum := 0
+	snapshot.EachValue(func(value int) { sum += value })
+	if sum != 16 || snapshot.Keys().Length() != 3 || snapshot.Values().Length() != 3 {
+		t.Errorf("Expected iteration to visit every pair, but got sum %d", sum)
+	}
+}
+
+// TestCOWMapZeroValue tests that the zero value of COWMap is ready to use.
+func TestCOWMapZeroValue(t *testing.T) {
+	var cowMap gomap.COWMap[string, int]
+	if cowMap.Length() != 0 || cowMap.Has("apple") || cowMap.Snapshot().Length() != 0 {
+		t.Errorf("Expected the zero value to be empty")
+	}
+	cowMap.Add("apple", 5)
+	if value, ok := cowMap.Get("apple"); !ok || value != 5 {
+		t.Errorf("Expected (5, true), but got (%d, %t)", value, ok)
+	}
+}
+
+// TestCOWMapReadersDoNotBlock tests that readers are not blocked by an Update in progress.
+func TestCOWMapReadersDoNotBlock(t *testing.T) {
+	cowMap := gomap.NewCOWMap[string, int](map[string]int{"apple": 5})
+	cowMap.Update(func(newMap *gomap.Map[string, int]) {
+		newMap.Add("apple", 10)
+		read := make(chan int)
+		go func() {
+			value, _ := cowMap.Get("apple")
+			read <- value + cowMap.Length() + cowMap.Snapshot().Length()
+		}()
+		select {
+		case value := <-read:
+			if value != 7 {
+				t.Errorf("Expected readers to observe the map before the update, but got %d", value)
+			}
+		case <-time.After(time.Second):
+			t.Errorf("Expected readers not to block during an update")
+		}
+	})
+	if value, _ := cowMap.Get("apple"); value != 10 {
+		t.Errorf("Expected the update to be published")
+	}
+}
@@ -0,0 +1,31 @@
+package gomap
+
+// DefaultMap is a Map that creates values for missing keys on demand using a factory function.
+// All Map methods are available through the embedded Map.
+type DefaultMap[K comparable, V any] struct {
+	Map[K, V]
+	factory func(key K) V
+}
+
+// NewDefaultMap creates an empty DefaultMap that uses the provided function to create values for missing keys.
+//
+//	// Create a new DefaultMap instance.
+//	defaultMap := gomap.NewDefaultMap(func(key string) *gomap.Map[string, int] {
+//		return &gomap.Map[string, int]{}
+//	})
+//	defaultMap.GetOrCreate("fruit").Add("apple", 5) // No need to check whether "fruit" exists first
+func NewDefaultMap[K comparable, V any](factory func(key K) V) *DefaultMap[K, V] {
+	return &DefaultMap[K, V]{Map: make(Map[K, V]), factory: factory}
+}
+
+// GetOrCreate returns the value associated with the provided key. If the key does not exist,
+// a value is created using the factory function, stored in the map and returned.
+//
+//	// Create a new DefaultMap instance.
+//	defaultMap := gomap.NewDefaultMap(func(key string) []string {
+//		return nil
+//	})
+//	defaultMap.Add("fruit", append(defaultMap.GetOrCreate("fruit"), "apple"))
+func (defaultMap *DefaultMap[K, V]) GetOrCreate(key K) V {
+	return defaultMap.ComputeIfAbsent(key, defaultMap.factory)
+}
@@ -0,0 +1,50 @@
+package gomap_test
+
+import (
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// TestDefaultMapGetOrCreate tests DefaultMap.GetOrCreate.
+func TestDefaultMapGetOrCreate(t *testing.T) {
+	calls := 0
+	defaultMap := gomap.NewDefaultMap(func(key string) *gomap.Map[string, int] {
+		calls++
+		return &gomap.Map[string, int]{}
+	})
+
+	// Test case 1: Missing keys are created and stored.
+	defaultMap.GetOrCreate("fruit").Add("apple", 5)
+	defaultMap.GetOrCreate("fruit").Add("banana", 3)
+	defaultMap.GetOrCreate("vegetable").Add("carrot", 1)
+	if calls != 2 {
+		t.Errorf("Expected the factory to be called twice, but got %d", calls)
+	}
+	if length := defaultMap.Fetch("fruit").Length(); length != 2 {
+		t.Errorf("Expected 2 fruits, but got %d", length)
+	}
+
+	// Test case 2: The embedded Map methods are available.
+	if defaultMap.Length() != 2 || !defaultMap.Has("vegetable") {
+		t.Errorf("Expected 2 keys, but got %d", defaultMap.Length())
+	}
+	defaultMap.Delete("vegetable")
+	if defaultMap.Has("vegetable") {
+		t.Errorf("Expected key 'vegetable' to be deleted")
+	}
+}
+
+// TestDefaultMapFactoryKey tests that DefaultMap passes the missing key to the factory.
+func TestDefaultMapFactoryKey(t *testing.T) {
+	defaultMap := gomap.NewDefaultMap(func(key string) int {
+		return len(key)
+	})
+	if value := defaultMap.GetOrCreate("banana"); value != 6 {
+		t.Errorf("Expected value 6, but got %d", value)
+	}
+	defaultMap.Add("banana", 1)
+	if value := defaultMap.GetOrCreate("banana"); value != 1 {
+		t.Errorf("Expected stored value 1, but got %d", value)
+	}
+}
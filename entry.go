@@ -0,0 +1,82 @@
+package gomap
+
+import (
+	"cmp"
+	"sort"
+
+	"github.com/lindsaygelle/slice"
+)
+
+// Entry represents a single key-value pair of a map.
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// FromEntries creates a new Map from the key-value pairs. If a key appears more than once, the last value is kept.
+//
+//	// Create a new Map instance.
+//	newMap := gomap.FromEntries(gomap.Entry[string, int]{"apple", 5}, gomap.Entry[string, int]{"banana", 3})
+//	// &map[apple:5 banana:3]
+func FromEntries[K comparable, V any](entries ...Entry[K, V]) *Map[K, V] {
+	newMap := make(Map[K, V], len(entries))
+	for _, entry := range entries {
+		newMap.Add(entry.Key, entry.Value)
+	}
+	return &newMap
+}
+
+// SortEntries sorts the key-value pairs in place in the order defined by the less function and returns them.
+// The sort is stable, so pairs that are neither less than each other keep their order.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3}
+//	entries := gomap.SortEntries(newMap.Entries(), func(a, b gomap.Entry[string, int]) bool {
+//		return a.Value < b.Value
+//	}) // &[{banana 3} {apple 5}]
+func SortEntries[K comparable, V any](entries *slice.Slice[Entry[K, V]], less func(a Entry[K, V], b Entry[K, V]) bool) *slice.Slice[Entry[K, V]] {
+	sort.SliceStable(*entries, func(i, j int) bool {
+		return less((*entries)[i], (*entries)[j])
+	})
+	return entries
+}
+
+// SortEntriesByKey sorts the key-value pairs in place in ascending order of their keys and returns them.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"banana": 3, "apple": 5}
+//	entries := gomap.SortEntriesByKey(newMap.Entries()) // &[{apple 5} {banana 3}]
+func SortEntriesByKey[K cmp.Ordered, V any](entries *slice.Slice[Entry[K, V]]) *slice.Slice[Entry[K, V]] {
+	return SortEntries(entries, func(a, b Entry[K, V]) bool {
+		return cmp.Less(a.Key, b.Key)
+	})
+}
+
+// SortEntriesByValue sorts the key-value pairs in place in ascending order of their values and returns them.
+// Pairs with equal values are ordered by their keys, so that the result does not depend on the order of the input.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "cherry": 3}
+//	entries := gomap.SortEntriesByValue(newMap.Entries()) // &[{banana 3} {cherry 3} {apple 5}]
+func SortEntriesByValue[K cmp.Ordered, V cmp.Ordered](entries *slice.Slice[Entry[K, V]]) *slice.Slice[Entry[K, V]] {
+	return SortEntries(entries, func(a, b Entry[K, V]) bool {
+		if c := cmp.Compare(a.Value, b.Value); c != 0 {
+			return c < 0
+		}
+		return cmp.Less(a.Key, b.Key)
+	})
+}
+
+// Zip creates a new Map that associates each key with the value at the same index. If the slices have different
+// lengths, the extra keys or values are ignored. If a key appears more than once, the last value is kept.
+//
+//	// Create a new Map instance.
+//	newMap := gomap.Zip([]string{"apple", "banana"}, []int{5, 3}) // &map[apple:5 banana:3]
+func Zip[K comparable, V any](keys []K, values []V) *Map[K, V] {
+	n := min(len(keys), len(values))
+	newMap := make(Map[K, V], n)
+	for i := 0; i < n; i++ {
+		newMap.Add(keys[i], values[i])
+	}
+	return &newMap
+}
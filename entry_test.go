@@ -0,0 +1,77 @@
+package gomap_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+	"github.com/lindsaygelle/slice"
+)
+
+// TestFromEntries tests FromEntries.
+func TestFromEntries(t *testing.T) {
+	newMap := gomap.FromEntries(
+		gomap.Entry[string, int]{Key: "apple", Value: 5},
+		gomap.Entry[string, int]{Key: "banana", Value: 3},
+		gomap.Entry[string, int]{Key: "apple", Value: 7},
+	)
+	if expected := (gomap.Map[string, int]{"apple": 7, "banana": 3}); !reflect.DeepEqual(*newMap, expected) {
+		t.Errorf("Expected %v, but got %v", expected, newMap)
+	}
+	original := &gomap.Map[string, int]{"a": 1, "b": 2, "c": 3}
+	if roundTrip := gomap.FromEntries(*original.Entries()...); !reflect.DeepEqual(roundTrip, original) {
+		t.Errorf("Expected %v, but got %v", original, roundTrip)
+	}
+}
+
+// TestSortEntries tests SortEntries.
+func TestSortEntries(t *testing.T) {
+	entries := &slice.Slice[gomap.Entry[string, int]]{{Key: "a", Value: 2}, {Key: "b", Value: 1}, {Key: "c", Value: 2}}
+	result := gomap.SortEntries(entries, func(a, b gomap.Entry[string, int]) bool {
+		return a.Value > b.Value
+	})
+	expected := slice.Slice[gomap.Entry[string, int]]{{Key: "a", Value: 2}, {Key: "c", Value: 2}, {Key: "b", Value: 1}}
+	if result != entries || !reflect.DeepEqual(*entries, expected) {
+		t.Errorf("Expected %v sorted in place, but got %v", expected, result)
+	}
+}
+
+// TestSortEntriesByKey tests SortEntriesByKey.
+func TestSortEntriesByKey(t *testing.T) {
+	entries := (&gomap.Map[string, int]{"cherry": 8, "apple": 5, "banana": 3}).Entries()
+	gomap.SortEntriesByKey(entries)
+	expected := slice.Slice[gomap.Entry[string, int]]{{Key: "apple", Value: 5}, {Key: "banana", Value: 3}, {Key: "cherry", Value: 8}}
+	if !reflect.DeepEqual(*entries, expected) {
+		t.Errorf("Expected %v, but got %v", expected, entries)
+	}
+}
+
+// TestSortEntriesByValue tests SortEntriesByValue.
+func TestSortEntriesByValue(t *testing.T) {
+	entries := (&gomap.Map[string, int]{"apple": 5, "cherry": 3, "banana": 3}).Entries()
+	gomap.SortEntriesByValue(entries)
+	expected := slice.Slice[gomap.Entry[string, int]]{{Key: "banana", Value: 3}, {Key: "cherry", Value: 3}, {Key: "apple", Value: 5}}
+	if !reflect.DeepEqual(*entries, expected) {
+		t.Errorf("Expected %v, but got %v", expected, entries)
+	}
+}
+
+// TestZip tests Zip.
+func TestZip(t *testing.T) {
+	tests := []struct {
+		keys     []string
+		values   []int
+		expected gomap.Map[string, int]
+	}{
+		{[]string{"a", "b"}, []int{1, 2}, gomap.Map[string, int]{"a": 1, "b": 2}},
+		{[]string{"a", "b", "c"}, []int{1, 2}, gomap.Map[string, int]{"a": 1, "b": 2}},
+		{[]string{"a"}, []int{1, 2}, gomap.Map[string, int]{"a": 1}},
+		{[]string{"a", "a"}, []int{1, 2}, gomap.Map[string, int]{"a": 2}},
+		{nil, nil, gomap.Map[string, int]{}},
+	}
+	for _, test := range tests {
+		if result := gomap.Zip(test.keys, test.values); !reflect.DeepEqual(*result, test.expected) {
+			t.Errorf("Expected Zip(%v, %v) to be %v, but got %v", test.keys, test.values, test.expected, result)
+		}
+	}
+}
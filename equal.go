@@ -0,0 +1,265 @@
+package gomap
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Equaler is implemented by values that define their own equality. Map methods that compare values,
+// such as Contains and Equal, always use reflect.DeepEqual; to compare values using their Equal method instead,
+// opt in with ContainsEqualer, DeleteManyValuesEqualer, EqualEqualer and IntersectionEqualer, or pass the method
+// to EqualFunc. Equal is not called when either value is a nil pointer, map or slice; two nil values are equal.
+//
+//	// Define a value type that ignores case when compared.
+//	type Name string
+//
+//	func (name Name) Equal(other Name) bool {
+//		return strings.EqualFold(string(name), string(other))
+//	}
+type Equaler[V any] interface {
+	Equal(other V) bool
+}
+
+// equalOperators caches whether comparing values of each type using == gives the same result as reflect.DeepEqual.
+var equalOperators sync.Map
+
+// equalFunc returns the function used by Map methods to compare values of type V. The result is always that
+// of reflect.DeepEqual, but values of predeclared scalar types are compared using == on their own type, and values
+// of other types for which == matches reflect.DeepEqual are compared using == on interfaces, which do not escape.
+func equalFunc[V any]() func(a V, b V) bool {
+	if fn, ok := equalScalar[V]().(func(a V, b V) bool); ok {
+		return fn
+	}
+	t := reflect.TypeOf((*V)(nil)).Elem()
+	exact, ok := equalOperators.Load(t)
+	if !ok {
+		exact = equalOperatorExact(t)
+		equalOperators.Store(t, exact)
+	}
+	if exact.(bool) {
+		return func(a V, b V) bool {
+			return any(a) == any(b)
+		}
+	}
+	return func(a V, b V) bool {
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// equalScalar returns a typed == comparison if V is a predeclared scalar type, or nil otherwise.
+func equalScalar[V any]() any {
+	switch any((*V)(nil)).(type) {
+	case *bool:
+		return equalTyped[bool]
+	case *int:
+		return equalTyped[int]
+	case *int8:
+		return equalTyped[int8]
+	case *int16:
+		return equalTyped[int16]
+	case *int32:
+		return equalTyped[int32]
+	case *int64:
+		return equalTyped[int64]
+	case *uint:
+		return equalTyped[uint]
+	case *uint8:
+		return equalTyped[uint8]
+	case *uint16:
+		return equalTyped[uint16]
+	case *uint32:
+		return equalTyped[uint32]
+	case *uint64:
+		return equalTyped[uint64]
+	case *uintptr:
+		return equalTyped[uintptr]
+	case *float32:
+		return equalTyped[float32]
+	case *float64:
+		return equalTyped[float64]
+	case *complex64:
+		return equalTyped[complex64]
+	case *complex128:
+		return equalTyped[complex128]
+	case *string:
+		return equalTyped[string]
+	}
+	return nil
+}
+
+// equalTyped compares the values using ==.
+func equalTyped[V comparable](a V, b V) bool {
+	return a == b
+}
+
+// equalEqualer compares the values using the Equal method of the first, unless either of them is nil.
+func equalEqualer[V Equaler[V]](a V, b V) bool {
+	if aNil, bNil := equalIsNil(a), equalIsNil(b); aNil || bNil {
+		return aNil == bNil
+	}
+	return a.Equal(b)
+}
+
+// equalIsNil checks if the value is nil or holds a nil pointer, map, slice, function or channel.
+func equalIsNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+// equalOperatorExact checks if comparing values of the type using == gives the same result as reflect.DeepEqual.
+// This holds for types built only from booleans, numbers, strings and channels.
+func equalOperatorExact(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128,
+		reflect.String, reflect.Chan, reflect.UnsafePointer:
+		return true
+	case reflect.Array:
+		return equalOperatorExact(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !equalOperatorExact(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// ContainsComparable checks if the given value is present in the map using == and returns the first key that matches the value.
+// It is a faster alternative to Map.Contains for comparable value types. Pointers are compared by address.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3}
+//	key, found := gomap.ContainsComparable(newMap, 5) // "apple", true
+func ContainsComparable[K comparable, V comparable](gomap *Map[K, V], value V) (K, bool) {
+	for key, v := range *gomap {
+		if v == value {
+			return key, true
+		}
+	}
+	var key K
+	return key, false
+}
+
+// DeleteManyValuesComparable removes all key-value pairs whose values are equal to any of the provided values using ==.
+// It is a faster alternative to Map.DeleteManyValues for comparable value types.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "orange": 10}
+//	gomap.DeleteManyValuesComparable(newMap, 5, 10) // &map[banana:3]
+func DeleteManyValuesComparable[K comparable, V comparable](gomap *Map[K, V], values ...V) *Map[K, V] {
+	set := make(map[V]struct{}, len(values))
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+	for key, value := range *gomap {
+		if _, ok := set[value]; ok {
+			delete(*gomap, key)
+		}
+	}
+	return gomap
+}
+
+// EqualComparable checks if the two maps contain the same key-value pairs, comparing values using ==.
+// It is a faster alternative to Map.Equal for comparable value types.
+//
+//	// Create two new Map instances.
+//	newMap1 := &gomap.Map[string, int]{"apple": 5}
+//	newMap2 := &gomap.Map[string, int]{"apple": 5}
+//	equal := gomap.EqualComparable(newMap1, newMap2) // true
+func EqualComparable[K comparable, V comparable](gomap *Map[K, V], other *Map[K, V]) bool {
+	if len(*gomap) != len(*other) {
+		return false
+	}
+	for key, value := range *gomap {
+		if v, ok := (*other)[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// IntersectionComparable creates a new map containing the key-value pairs that exist in both maps with equal values,
+// comparing values using ==. It is a faster alternative to Map.Intersection for comparable value types.
+//
+//	// Create two new Map instances.
+//	newMap1 := &gomap.Map[string, int]{"apple": 5, "orange": 8}
+//	newMap2 := &gomap.Map[string, int]{"apple": 5, "orange": 9}
+//	newMap := gomap.IntersectionComparable(newMap1, newMap2) // &map[apple:5]
+func IntersectionComparable[K comparable, V comparable](gomap *Map[K, V], other *Map[K, V]) *Map[K, V] {
+	newMap := make(Map[K, V])
+	for key, value := range *gomap {
+		if v, ok := (*other)[key]; ok && v == value {
+			newMap[key] = value
+		}
+	}
+	return &newMap
+}
+
+// ContainsEqualer checks if the given value is present in the map, comparing values using their Equal method,
+// and returns the first key that matches the value.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, Name]{"a": "Apple"}
+//	key, found := gomap.ContainsEqualer(newMap, "APPLE") // "a", true
+func ContainsEqualer[K comparable, V Equaler[V]](gomap *Map[K, V], value V) (K, bool) {
+	for key, v := range *gomap {
+		if equalEqualer(v, value) {
+			return key, true
+		}
+	}
+	var key K
+	return key, false
+}
+
+// DeleteManyValuesEqualer removes all key-value pairs whose values are equal to any of the provided values,
+// comparing values using their Equal method.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, Name]{"a": "Apple", "b": "Banana"}
+//	gomap.DeleteManyValuesEqualer(newMap, "APPLE") // &map[b:Banana]
+func DeleteManyValuesEqualer[K comparable, V Equaler[V]](gomap *Map[K, V], values ...V) *Map[K, V] {
+	for key, value := range *gomap {
+		for _, v := range values {
+			if equalEqualer(v, value) {
+				delete(*gomap, key)
+				break
+			}
+		}
+	}
+	return gomap
+}
+
+// EqualEqualer checks if the two maps contain the same keys, comparing their values using their Equal method.
+// Standard library types with an Equal method can be compared this way, so that time.Time values are equal
+// when they represent the same instant, whatever their location.
+//
+//	// Create two new Map instances.
+//	newMap1 := &gomap.Map[string, time.Time]{"start": start}
+//	newMap2 := &gomap.Map[string, time.Time]{"start": start.UTC()}
+//	equal := gomap.EqualEqualer(newMap1, newMap2) // true
+func EqualEqualer[K comparable, V Equaler[V]](gomap *Map[K, V], other *Map[K, V]) bool {
+	return gomap.EqualFunc(other, equalEqualer[V])
+}
+
+// IntersectionEqualer creates a new map containing the key-value pairs that exist in both maps with equal values,
+// comparing values using their Equal method.
+//
+//	// Create two new Map instances.
+//	newMap1 := &gomap.Map[string, Name]{"a": "Apple", "b": "Banana"}
+//	newMap2 := &gomap.Map[string, Name]{"a": "APPLE", "b": "Cherry"}
+//	newMap := gomap.IntersectionEqualer(newMap1, newMap2) // &map[a:Apple]
+func IntersectionEqualer[K comparable, V Equaler[V]](gomap *Map[K, V], other *Map[K, V]) *Map[K, V] {
+	return gomap.IntersectionFunc(other, func(key K, a V, b V) bool {
+		return equalEqualer(a, b)
+	})
+}
@@ -0,0 +1,166 @@
+package gomap_test
+
+import (
+	"math"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// caseless is a string that implements Equaler by ignoring case.
+type caseless string
+
+// Equal checks if the strings are equal ignoring case.
+func (value caseless) Equal(other caseless) bool {
+	return strings.EqualFold(string(value), string(other))
+}
+
+// version is a value that implements Equaler with a pointer receiver.
+type version struct {
+	Major int
+}
+
+// Equal checks if the versions have the same major version.
+func (value *version) Equal(other *version) bool {
+	return value.Major == other.Major
+}
+
+// point is a comparable struct value.
+type point struct {
+	X, Y int
+}
+
+// TestEqualer tests that Map methods use reflect.DeepEqual and the Equaler functions use Equal.
+func TestEqualer(t *testing.T) {
+	newMap := &gomap.Map[string, caseless]{"a": "Apple", "b": "Banana"}
+	if _, ok := newMap.Contains("APPLE"); ok {
+		t.Errorf("Expected Contains not to call Equal")
+	}
+	if newMap.Equal(&gomap.Map[string, caseless]{"a": "apple", "b": "BANANA"}) {
+		t.Errorf("Expected Equal not to call Equal on the values")
+	}
+	if key, ok := gomap.ContainsEqualer(newMap, "APPLE"); !ok || key != "a" {
+		t.Errorf("Expected 'a', true, but got %q, %t", key, ok)
+	}
+	if !gomap.EqualEqualer(newMap, &gomap.Map[string, caseless]{"a": "apple", "b": "BANANA"}) {
+		t.Errorf("Expected the maps to be equal ignoring case")
+	}
+	if intersection := gomap.IntersectionEqualer(newMap, &gomap.Map[string, caseless]{"a": "apple", "b": "cherry"}); intersection.Length() != 1 || !intersection.Has("a") {
+		t.Errorf("Expected the intersection to be {a: Apple}, but got %v", intersection)
+	}
+	gomap.DeleteManyValuesEqualer(newMap, "banana")
+	if newMap.Has("b") || !newMap.Has("a") {
+		t.Errorf("Expected 'b' to be deleted, but got %v", newMap)
+	}
+	values := &gomap.Map[string, any]{"a": caseless("Apple"), "b": []int{1}}
+	if _, ok := values.Contains([]int{1}); !ok {
+		t.Errorf("Expected interface values to be compared using reflect.DeepEqual")
+	}
+}
+
+// TestEqualPointers tests that pointer values are still compared deeply.
+func TestEqualPointers(t *testing.T) {
+	a, b := 1, 1
+	if !(&gomap.Map[string, *int]{"a": &a}).Equal(&gomap.Map[string, *int]{"a": &b}) {
+		t.Errorf("Expected pointers to equal values to be equal")
+	}
+	if gomap.EqualComparable(&gomap.Map[string, *int]{"a": &a}, &gomap.Map[string, *int]{"a": &b}) {
+		t.Errorf("Expected EqualComparable to compare pointers by address")
+	}
+}
+
+// TestEqualerNil tests that Equal is not called on nil values.
+func TestEqualerNil(t *testing.T) {
+	a, b := &version{1}, &version{1}
+	tests := []struct {
+		x, y     *version
+		expected bool
+	}{
+		{a, b, true},
+		{nil, nil, true},
+		{a, nil, false},
+		{nil, b, false},
+	}
+	for _, test := range tests {
+		if equal := gomap.EqualEqualer(&gomap.Map[string, *version]{"a": test.x}, &gomap.Map[string, *version]{"a": test.y}); equal != test.expected {
+			t.Errorf("EqualEqualer(%v, %v): expected %t, but got %t", test.x, test.y, test.expected, equal)
+		}
+	}
+}
+
+// TestEqualerStandardLibrary tests that standard library types are only compared using their Equal method on request.
+func TestEqualerStandardLibrary(t *testing.T) {
+	instant := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	a, b := &gomap.Map[string, time.Time]{"a": instant}, &gomap.Map[string, time.Time]{"a": instant.In(time.FixedZone("UTC+1", 3600))}
+	if a.Equal(b) || !gomap.EqualEqualer(a, b) {
+		t.Errorf("Expected only EqualEqualer to treat times representing the same instant as equal")
+	}
+	ip4, ip16 := &gomap.Map[string, net.IP]{"a": net.IP{127, 0, 0, 1}}, &gomap.Map[string, net.IP]{"a": net.IPv4(127, 0, 0, 1)}
+	if ip4.Equal(ip16) || !gomap.EqualEqualer(ip4, ip16) {
+		t.Errorf("Expected only EqualEqualer to treat the 4-byte and 16-byte forms of an IP address as equal")
+	}
+}
+
+// TestEqualFuncResults tests that the fast paths used by Map methods give the same results as reflect.DeepEqual.
+func TestEqualFuncResults(t *testing.T) {
+	type celsius float64
+	nan := math.NaN()
+	if (&gomap.Map[int, float64]{1: nan}).Equal(&gomap.Map[int, float64]{1: nan}) || (&gomap.Map[int, celsius]{1: celsius(nan)}).Equal(&gomap.Map[int, celsius]{1: celsius(nan)}) {
+		t.Errorf("Expected NaN not to equal itself, as with reflect.DeepEqual")
+	}
+	if !(&gomap.Map[int, point]{1: {1, 2}}).Equal(&gomap.Map[int, point]{1: {1, 2}}) || (&gomap.Map[int, string]{1: "a"}).Equal(&gomap.Map[int, string]{1: "b"}) {
+		t.Errorf("Expected values to be compared by value")
+	}
+	large := &gomap.Map[int, point]{}
+	for i := 0; i < 1000; i++ {
+		large.Add(i, point{i, i})
+	}
+	if allocs := testing.AllocsPerRun(10, func() { large.Equal(large) }); allocs > 1 {
+		t.Errorf("Expected comparing values not to allocate, but got %v allocations", allocs)
+	}
+}
+
+// TestContainsComparable tests ContainsComparable.
+func TestContainsComparable(t *testing.T) {
+	newMap := &gomap.Map[string, point]{"a": {1, 2}, "b": {3, 4}}
+	if key, ok := gomap.ContainsComparable(newMap, point{3, 4}); !ok || key != "b" {
+		t.Errorf("Expected 'b', true, but got %q, %t", key, ok)
+	}
+	if _, ok := gomap.ContainsComparable(newMap, point{5, 6}); ok {
+		t.Errorf("Expected {5 6} not to be found")
+	}
+}
+
+// TestDeleteManyValuesComparable tests DeleteManyValuesComparable.
+func TestDeleteManyValuesComparable(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "orange": 10}
+	gomap.DeleteManyValuesComparable(newMap, 5, 10)
+	if !newMap.Equal(&gomap.Map[string, int]{"banana": 3}) {
+		t.Errorf("Expected {banana: 3}, but got %v", newMap)
+	}
+}
+
+// TestEqualComparable tests EqualComparable.
+func TestEqualComparable(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3}
+	if !gomap.EqualComparable(newMap, &gomap.Map[string, int]{"apple": 5, "banana": 3}) {
+		t.Errorf("Expected the maps to be equal")
+	}
+	if gomap.EqualComparable(newMap, &gomap.Map[string, int]{"apple": 5, "cherry": 3}) {
+		t.Errorf("Expected maps with different keys not to be equal")
+	}
+	if gomap.EqualComparable(newMap, &gomap.Map[string, int]{"apple": 5}) {
+		t.Errorf("Expected maps with different lengths not to be equal")
+	}
+}
+
+// TestIntersectionComparable tests IntersectionComparable.
+func TestIntersectionComparable(t *testing.T) {
+	newMap := gomap.IntersectionComparable(&gomap.Map[string, int]{"apple": 5, "orange": 8}, &gomap.Map[string, int]{"apple": 5, "orange": 9})
+	if !newMap.Equal(&gomap.Map[string, int]{"apple": 5}) {
+		t.Errorf("Expected {apple: 5}, but got %v", newMap)
+	}
+}
@@ -0,0 +1,141 @@
+package gomap_test
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// fuzzOps is the number of operations interpreted by FuzzMap.
+const fuzzOps = 11
+
+// fuzzModel checks that a Map holds the same key-value pairs as a plain Go map.
+func fuzzModel(t *testing.T, step string, name string, got *gomap.Map[int, int], model map[int]int) {
+	t.Helper()
+	if got.Length() != len(model) {
+		t.Fatalf("%s: expected %s to have length %d, but got %d (%v, model %v)", step, name, len(model), got.Length(), got, model)
+	}
+	if !reflect.DeepEqual(map[int]int(*got), model) {
+		t.Fatalf("%s: expected %s to be %v, but got %v", step, name, model, got)
+	}
+	if keys := got.Keys(); keys.Length() != len(model) {
+		t.Fatalf("%s: expected %s to have %d keys, but got %v", step, name, len(model), keys)
+	}
+	for key, value := range model {
+		if v, ok := got.Get(key); !ok || v != value || !got.Has(key) {
+			t.Fatalf("%s: expected %s to hold %d: %d, but got (%d, %v)", step, name, key, value, v, ok)
+		}
+	}
+}
+
+// fuzzSorted returns the values sorted.
+func fuzzSorted(values []int) []int {
+	values = append([]int{}, values...)
+	sort.Ints(values)
+	return values
+}
+
+// FuzzMap executes random sequences of operations on two maps and checks them against a reference model
+// after each step. Each operation is encoded as three bytes: the operation and target map, a key and a value.
+func FuzzMap(f *testing.F) {
+	f.Add([]byte{0, 1, 1, 0, 2, 2, 10, 3, 3, 4, 0, 0, 6, 1, 9})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		maps := [2]*gomap.Map[int, int]{{}, {}}
+		models := [2]map[int]int{{}, {}}
+		for i := 0; i+2 < len(data); i += 3 {
+			op, target := int(data[i])%fuzzOps, int(data[i])/fuzzOps%2
+			key, value := int(data[i+1]%8), int(data[i+2])
+			current, model := maps[target], models[target]
+			other, otherModel := maps[1-target], models[1-target]
+			step := fmt.Sprintf("step %d (op %d on map %d, key %d, value %d)", i/3, op, target, key, value)
+			switch op {
+			case 0:
+				current.Add(key, value)
+				model[key] = value
+			case 1:
+				_, exists := model[key]
+				if ok := current.AddOK(key, value); ok == exists {
+					t.Fatalf("%s: expected AddOK to return %v, but got %v", step, !exists, ok)
+				}
+				if !exists {
+					model[key] = value
+				}
+			case 2:
+				current.Delete(key)
+				delete(model, key)
+			case 3:
+				if popped := current.Pop(key); popped != model[key] {
+					t.Fatalf("%s: expected Pop to return %d, but got %d", step, model[key], popped)
+				}
+				delete(model, key)
+			case 4:
+				current.TakeFrom(other)
+				for k, v := range otherModel {
+					model[k] = v
+					delete(otherModel, k)
+				}
+			case 5:
+				current.EmptyInto(other)
+				for k, v := range model {
+					otherModel[k] = v
+					delete(model, k)
+				}
+			case 6:
+				current.Merge(other)
+				for k, v := range otherModel {
+					model[k] = v
+				}
+			case 7:
+				current.DeleteManyFunc(func(k int, v int) bool {
+					return (k+v)%3 == value%3
+				})
+				for k, v := range model {
+					if (k+v)%3 == value%3 {
+						delete(model, k)
+					}
+				}
+			case 8:
+				popped := current.PopManyFunc(func(k int, v int) bool {
+					return k >= key
+				})
+				var expected []int
+				for k, v := range model {
+					if k >= key {
+						expected = append(expected, v)
+						delete(model, k)
+					}
+				}
+				if got := fuzzSorted(*popped); !reflect.DeepEqual(got, fuzzSorted(expected)) {
+					t.Fatalf("%s: expected PopManyFunc to return %v, but got %v", step, fuzzSorted(expected), got)
+				}
+			case 9:
+				current.ReplaceMany(func(k int, v int) (int, bool) {
+					return v + value, k%2 == value%2
+				})
+				for k, v := range model {
+					if k%2 == value%2 {
+						model[k] = v + value
+					}
+				}
+			case 10:
+				// MapBreak stops at a random point in iteration order, so only order-independent properties are checked.
+				mapped := current.MapBreak(func(k int, v int) (int, bool) {
+					return v * 2, k != key
+				})
+				if _, ok := model[key]; !ok && mapped.Length() != len(model) {
+					t.Fatalf("%s: expected MapBreak without a break to map all %d pairs, but got %v", step, len(model), mapped)
+				}
+				mapped.Each(func(k int, v int) {
+					if expected, ok := model[k]; !ok || k == key || v != expected*2 {
+						t.Fatalf("%s: expected MapBreak to only hold mapped pairs before the break, but got %d: %d", step, k, v)
+					}
+				})
+			}
+			fuzzModel(t, step, "map 0", maps[0], models[0])
+			fuzzModel(t, step, "map 1", maps[1], models[1])
+		}
+	})
+}
@@ -1,7 +1,8 @@
 package gomap
 
 import (
-	"reflect"
+	"context"
+	"sort"
 
 	"github.com/lindsaygelle/slice"
 )
@@ -9,6 +10,9 @@ import (
 // Map represents a generic map that maps keys of type K to values of type V.
 type Map[K comparable, V any] map[K]V
 
+// eachCtxInterval is the number of key-value pairs EachCtx visits between checks of its context.
+const eachCtxInterval = 64
+
 // Add inserts a new key-value pair into the map or updates the existing value associated with the provided key.
 // If the key already exists, the corresponding value is updated. If the key is new, a new key-value pair is added to the map.
 //
@@ -153,9 +157,75 @@ func (gomap *Map[K, V]) AddOK(key K, value V) bool {
 	return ok
 }
 
+// Compute computes a new value for the provided key from its current value. The function receives the current value
+// and a boolean indicating whether the key exists. If the function returns true, the computed value is stored;
+// if it returns false, the key is deleted. It returns the value now associated with the key and whether the key exists.
+//
+//	// Create a new Map instance.
+//	newMap := make(gomap.Map[string, int])
+//	newMap.Add("apple", 5)
+//	value, ok := newMap.Compute("apple", func(value int, ok bool) (int, bool) {
+//		return value + 1, true
+//	}) // 6, true
+//	value, ok = newMap.Compute("apple", func(value int, ok bool) (int, bool) {
+//		return value, false
+//	}) // 0, false, "apple" is deleted
+func (gomap *Map[K, V]) Compute(key K, fn func(value V, ok bool) (V, bool)) (V, bool) {
+	value, ok := gomap.Get(key)
+	value, ok = fn(value, ok)
+	if !ok {
+		gomap.Delete(key)
+		var zero V
+		return zero, false
+	}
+	gomap.Add(key, value)
+	return value, true
+}
+
+// ComputeIfAbsent returns the value associated with the provided key. If the key does not exist,
+// the function is called to create a value, which is stored in the map and returned.
+//
+//	// Create a new Map instance.
+//	newMap := make(gomap.Map[string, []int])
+//	values := newMap.ComputeIfAbsent("apple", func(key string) []int {
+//		return make([]int, 0)
+//	}) // Stores and returns an empty slice for "apple"
+func (gomap *Map[K, V]) ComputeIfAbsent(key K, fn func(key K) V) V {
+	value, ok := gomap.Get(key)
+	if !ok {
+		value = fn(key)
+		gomap.Add(key, value)
+	}
+	return value
+}
+
+// ComputeIfPresent computes a new value for the provided key if it exists. If the function returns true,
+// the computed value is stored; if it returns false, the key is deleted. It returns the value now associated
+// with the key and whether the key exists. If the key does not exist, the function is not called.
+//
+//	// Create a new Map instance.
+//	newMap := make(gomap.Map[string, int])
+//	newMap.Add("apple", 5)
+//	value, ok := newMap.ComputeIfPresent("apple", func(key string, value int) (int, bool) {
+//		return value * 2, true
+//	}) // 10, true
+//	value, ok = newMap.ComputeIfPresent("banana", func(key string, value int) (int, bool) {
+//		return value * 2, true
+//	}) // 0, false
+func (gomap *Map[K, V]) ComputeIfPresent(key K, fn func(key K, value V) (V, bool)) (V, bool) {
+	value, ok := gomap.Get(key)
+	if !ok {
+		return value, false
+	}
+	return gomap.Compute(key, func(value V, _ bool) (V, bool) {
+		return fn(key, value)
+	})
+}
+
 // Contains checks if the given value is present in the map and returns the first key-value pair that matches the value.
 // It takes a value as input and returns the key and a boolean indicating whether the value is found in the map.
 // If the value is found, it returns the corresponding key and true. If the value is not found, it returns the zero value for the key type and false.
+// Values are compared using reflect.DeepEqual; use ContainsEqualer to compare them using their Equal method.
 //
 //	// Create a new Map instance.
 //	newMap := make(gomap.Map[string, int])
@@ -163,8 +233,32 @@ func (gomap *Map[K, V]) AddOK(key K, value V) bool {
 func (gomap *Map[K, V]) Contains(value V) (K, bool) {
 	var k K
 	var ok bool
+	equal := equalFunc[V]()
 	gomap.EachBreak(func(key K, v V) bool {
-		ok = reflect.DeepEqual(v, value)
+		ok = equal(v, value)
+		if ok {
+			k = key
+		}
+		return !ok
+	})
+	return k, ok
+}
+
+// ContainsFirst checks if the given value is present in the map and returns the first matching key in the order
+// defined by the less function, so that the result does not depend on iteration order when several keys hold the value.
+// Values are compared using reflect.DeepEqual.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"orange": 5, "apple": 5, "banana": 3}
+//	key, found := newMap.ContainsFirst(5, func(a, b string) bool {
+//		return a < b
+//	}) // "apple", true
+func (gomap *Map[K, V]) ContainsFirst(value V, less func(a K, b K) bool) (K, bool) {
+	var k K
+	var ok bool
+	equal := equalFunc[V]()
+	gomap.EachSortedBreak(less, func(key K, v V) bool {
+		ok = equal(v, value)
 		if ok {
 			k = key
 		}
@@ -267,7 +361,7 @@ func (gomap *Map[K, V]) DeleteManyOK(keys ...K) *slice.Slice[bool] {
 }
 
 // DeleteManyValues removes key-value pairs from the map based on the provided values. If a value exists in the gomap,
-// the corresponding key-value pair is deleted.
+// the corresponding key-value pair is deleted. Values are compared using reflect.DeepEqual.
 //
 //	// Create a new Map instance.
 //	newMap := make(gomap.Map[string, int])
@@ -278,9 +372,10 @@ func (gomap *Map[K, V]) DeleteManyOK(keys ...K) *slice.Slice[bool] {
 //	newMap.DeleteManyValues(5, 10)
 //	// Map after deletion: {"banana": 3}
 func (gomap *Map[K, V]) DeleteManyValues(values ...V) *Map[K, V] {
+	equal := equalFunc[V]()
 	for key, value := range *gomap {
 		for _, v := range values {
-			if reflect.DeepEqual(v, value) {
+			if equal(v, value) {
 				gomap.Delete(key)
 			}
 		}
@@ -355,6 +450,69 @@ func (gomap *Map[K, V]) EachBreak(fn func(key K, value V) bool) *Map[K, V] {
 	return gomap
 }
 
+// EachByKeys applies the provided function to the key-value pairs of the provided keys, in the order of the keys.
+// Keys that are not in the map are skipped.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "cherry": 8}
+//	newMap.EachByKeys([]string{"cherry", "kiwi", "apple"}, func(key string, value int) {
+//		fmt.Println(key, value)
+//	})
+//	// Output: "cherry 8", "apple 5"
+func (gomap *Map[K, V]) EachByKeys(keys []K, fn func(key K, value V)) *Map[K, V] {
+	for _, key := range keys {
+		if value, ok := gomap.Get(key); ok {
+			fn(key, value)
+		}
+	}
+	return gomap
+}
+
+// EachCtx executes the provided function for each key-value pair in the map and returns the first error it returns.
+// The context is checked every 64 pairs, so that iterating over a large map can be cancelled;
+// if the context is done, iteration stops and the context's error is returned.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3}
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+//	defer cancel()
+//	err := newMap.EachCtx(ctx, func(key string, value int) error {
+//		return process(key, value)
+//	})
+func (gomap *Map[K, V]) EachCtx(ctx context.Context, fn func(key K, value V) error) error {
+	i := 0
+	for key, value := range *gomap {
+		if i%eachCtxInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		i++
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EachErr executes the provided function for each key-value pair in the map and stops at the first error,
+// which is returned.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, string]{"a": "1", "b": "x"}
+//	err := newMap.EachErr(func(key string, value string) error {
+//		_, err := strconv.Atoi(value)
+//		return err
+//	}) // strconv.Atoi: parsing "x": invalid syntax
+func (gomap *Map[K, V]) EachErr(fn func(key K, value V) error) error {
+	for key, value := range *gomap {
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // EachKey iterates over the keys in the map and applies a function to each key.
 //
 //	// Create a new Map instance.
@@ -394,6 +552,43 @@ func (gomap *Map[K, V]) EachKeyBreak(fn func(key K) bool) *Map[K, V] {
 	})
 }
 
+// EachSorted applies the provided function to each key-value pair in the map in the order of the keys defined by
+// the less function, so that the order of calls is the same on every run. Keys that less does not order
+// are ordered as in SortedKeys.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"cherry": 8, "apple": 5, "banana": 3}
+//	newMap.EachSorted(func(a, b string) bool {
+//		return a < b
+//	}, func(key string, value int) {
+//		fmt.Println(key, value)
+//	})
+//	// Output: "apple 5", "banana 3", "cherry 8"
+func (gomap *Map[K, V]) EachSorted(less func(a K, b K) bool, fn func(key K, value V)) *Map[K, V] {
+	return gomap.EachByKeys(*gomap.SortedKeys(less), fn)
+}
+
+// EachSortedBreak applies the provided function to each key-value pair in the map in the order of the keys defined by
+// the less function until the provided function returns false, so that the iteration stops at the same pair on every run.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"cherry": 8, "apple": 5, "banana": 3}
+//	newMap.EachSortedBreak(func(a, b string) bool {
+//		return a < b
+//	}, func(key string, value int) bool {
+//		fmt.Println(key, value)
+//		return key != "banana"
+//	})
+//	// Output: "apple 5", "banana 3"
+func (gomap *Map[K, V]) EachSortedBreak(less func(a K, b K) bool, fn func(key K, value V) bool) *Map[K, V] {
+	for _, key := range *gomap.SortedKeys(less) {
+		if !fn(key, (*gomap)[key]) {
+			break
+		}
+	}
+	return gomap
+}
+
 // EachValue iterates over the values in the map and applies a function to each value.
 //
 //	// Create a new Map instance.
@@ -451,8 +646,20 @@ func (gomap *Map[K, V]) EmptyInto(other *Map[K, V]) *Map[K, V] {
 	return gomap
 }
 
+// Entries returns a slice containing the key-value pairs of the map. The order of the pairs is not specified;
+// use SortedEntries, SortEntries or SortEntriesByKey for a defined order.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3}
+//	entries := newMap.Entries() // &[{apple 5} {banana 3}] in any order
+func (gomap *Map[K, V]) Entries() *slice.Slice[Entry[K, V]] {
+	entries := slice.Slice[Entry[K, V]](gomap.entries())
+	return &entries
+}
+
 // Equal checks if the current map is equal to another map by comparing the key-value pairs directly using reflect.DeepEqual.
 // It takes another map as input and returns true if the two hashtables are equal, false otherwise.
+// Use EqualEqualer to compare values such as time.Time using their Equal method.
 //
 //	// Create a new Map instance.
 //	newMap1 := make(gomap.Map[string, int])
@@ -466,9 +673,7 @@ func (gomap *Map[K, V]) EmptyInto(other *Map[K, V]) *Map[K, V] {
 //
 //	equal := newMap1.Equal(newMap2)  // Returns true because newMap1 and newMap2 have the same key-value pairs
 func (gomap *Map[K, V]) Equal(other *Map[K, V]) bool {
-	return gomap.EqualFunc(other, func(a, b V) bool {
-		return reflect.DeepEqual(a, b)
-	})
+	return gomap.EqualFunc(other, equalFunc[V]())
 }
 
 // EqualFunc checks if the current map is equal to another map based on a provided comparison function.
@@ -567,6 +772,72 @@ func (gomap *Map[K, V]) Filter(fn func(key K, value V) bool) *Map[K, V] {
 	return &other
 }
 
+// FilterCtx creates a new map containing the key-value pairs for which the provided function returns true.
+// It stops at the first error returned by the function, or when the context is done, and returns the error.
+// The context is checked every 64 pairs.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+//	filtered, err := newMap.FilterCtx(ctx, func(key string, value string) (bool, error) {
+//		n, err := strconv.Atoi(value)
+//		return n > 1, err
+//	}) // &map[b:2], nil
+func (gomap *Map[K, V]) FilterCtx(ctx context.Context, fn func(key K, value V) (bool, error)) (*Map[K, V], error) {
+	filteredMap := make(Map[K, V])
+	err := gomap.EachCtx(ctx, func(key K, value V) error {
+		ok, err := fn(key, value)
+		if ok && err == nil {
+			filteredMap.Add(key, value)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &filteredMap, nil
+}
+
+// FilterErr creates a new map containing the key-value pairs for which the provided function returns true.
+// It stops at the first error returned by the function and returns it.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+//	filtered, err := newMap.FilterErr(func(key string, value string) (bool, error) {
+//		n, err := strconv.Atoi(value)
+//		return n > 1, err
+//	}) // &map[b:2], nil
+func (gomap *Map[K, V]) FilterErr(fn func(key K, value V) (bool, error)) (*Map[K, V], error) {
+	filteredMap := make(Map[K, V])
+	for key, value := range *gomap {
+		ok, err := fn(key, value)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filteredMap.Add(key, value)
+		}
+	}
+	return &filteredMap, nil
+}
+
+// Freeze returns a read-only snapshot of the map. The values are deep copied, so later changes
+// to the map or to the maps, slices and pointers held in its values are not reflected in the snapshot,
+// and the snapshot returns deep copies of its values, so they cannot be used to change it either.
+// Keys, unexported struct fields, channels and functions are copied by assignment, so the snapshot is not
+// deep-immutable for values that hold maps, slices or pointers in unexported fields, such as a struct with
+// an unexported cache map: changes made through those fields are visible in the snapshot and the live map.
+// Use values whose mutable state is exported, or copy them with a Clone method before calling Freeze.
+//
+//	// Create a new Map instance.
+//	newMap := make(gomap.Map[string, []int])
+//	newMap.Add("apple", []int{5})
+//	snapshot := newMap.Freeze()
+//	newMap.Fetch("apple")[0] = 10
+//	value := snapshot.Fetch("apple") // [5]
+func (gomap *Map[K, V]) Freeze() View[K, V] {
+	return &frozenView[K, V]{view: &view[K, V]{gomap: deepCopyValues(gomap)}}
+}
+
 // GetMany retrieves the values associated with the provided keys from the map. It accepts a variadic number of keys,
 // and returns a slice containing the values corresponding to the keys found in the map. If a key is not found in the gomap,
 // the corresponding position in the returned slice will be the zero value for the value type.
@@ -626,6 +897,22 @@ func (gomap *Map[K, V]) HasMany(keys ...K) *slice.Slice[bool] {
 	return &values
 }
 
+// Immutable returns an ImmutableMap containing the key-value pairs of the map.
+// Later changes to the map are not reflected in the returned ImmutableMap.
+//
+//	// Create a new Map instance.
+//	newMap := make(gomap.Map[string, int])
+//	newMap.Add("apple", 5)
+//	immutableMap := newMap.Immutable()
+//	newMap.Add("banana", 3) // immutableMap still only holds "apple"
+func (gomap *Map[K, V]) Immutable() *ImmutableMap[K, V] {
+	builder := NewImmutableMapBuilder[K, V]()
+	gomap.Each(func(key K, value V) {
+		builder.Set(key, value)
+	})
+	return builder.Build()
+}
+
 // Intersection creates a new map containing key-value pairs that exist in both the current map and another map.
 // It compares values using reflect.DeepEqual to determine equality between the pairs.
 // It takes another map as input and returns a new map containing the intersecting key-value pairs.
@@ -641,8 +928,9 @@ func (gomap *Map[K, V]) HasMany(keys ...K) *slice.Slice[bool] {
 //
 //	newMap := newMap1.Intersection(newMap2)  // Creates a new map with the pair "apple": 5
 func (gomap *Map[K, V]) Intersection(other *Map[K, V]) *Map[K, V] {
+	equal := equalFunc[V]()
 	return gomap.IntersectionFunc(other, func(key K, a, b V) bool {
-		return reflect.DeepEqual(a, b)
+		return equal(a, b)
 	})
 }
 
@@ -787,6 +1075,52 @@ func (gomap *Map[K, V]) MapBreak(fn func(key K, value V) (V, bool)) *Map[K, V] {
 	return &newMap
 }
 
+// MapCtx creates a new map with the values returned by the provided function for each key-value pair.
+// It stops at the first error returned by the function, or when the context is done, and returns the error.
+// The context is checked every 64 pairs. The original map remains unchanged.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+//	parsed, err := newMap.MapCtx(ctx, func(key string, value string) (string, error) {
+//		n, err := strconv.Atoi(value)
+//		return strconv.Itoa(n * 2), err
+//	}) // &map[a:2 b:4], nil
+func (gomap *Map[K, V]) MapCtx(ctx context.Context, fn func(key K, value V) (V, error)) (*Map[K, V], error) {
+	newMap := make(Map[K, V], len(*gomap))
+	err := gomap.EachCtx(ctx, func(key K, value V) error {
+		value, err := fn(key, value)
+		if err == nil {
+			newMap.Add(key, value)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &newMap, nil
+}
+
+// MapErr creates a new map with the values returned by the provided function for each key-value pair.
+// It stops at the first error returned by the function and returns it. The original map remains unchanged.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+//	parsed, err := newMap.MapErr(func(key string, value string) (string, error) {
+//		n, err := strconv.Atoi(value)
+//		return strconv.Itoa(n * 2), err
+//	}) // &map[a:2 b:4], nil
+func (gomap *Map[K, V]) MapErr(fn func(key K, value V) (V, error)) (*Map[K, V], error) {
+	newMap := make(Map[K, V], len(*gomap))
+	for key, value := range *gomap {
+		value, err := fn(key, value)
+		if err != nil {
+			return nil, err
+		}
+		newMap.Add(key, value)
+	}
+	return &newMap, nil
+}
+
 // Merge merges all key-value pairs from another map into the current map.
 // It takes another map as input and adds all its key-value pairs to the current map.
 //
@@ -906,6 +1240,101 @@ func (gomap *Map[K, V]) NotMany(keys ...K) *slice.Slice[bool] {
 	return &values
 }
 
+// ParallelEach executes the provided function for each key-value pair in the map on at most limit goroutines.
+// The map is partitioned into chunks that are processed concurrently, so the function must be safe for concurrent use.
+// A limit less than 1 uses runtime.GOMAXPROCS(0) goroutines. Processing stops at the first error returned by the function,
+// or when the context is done, and that error is returned. The map must not be modified until ParallelEach returns.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, string]{"a": "https://example.com/a", "b": "https://example.com/b"}
+//	err := newMap.ParallelEach(ctx, 8, func(ctx context.Context, key string, value string) error {
+//		return fetch(ctx, value)
+//	})
+func (gomap *Map[K, V]) ParallelEach(ctx context.Context, limit int, fn func(ctx context.Context, key K, value V) error) error {
+	entries := gomap.entries()
+	return parallelChunks(ctx, len(entries), limit, func(ctx context.Context, start int, end int) error {
+		for _, entry := range entries[start:end] {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := fn(ctx, entry.Key, entry.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ParallelFilter creates a new map containing the key-value pairs for which the provided function returns true,
+// calling the function on at most limit goroutines. A limit less than 1 uses runtime.GOMAXPROCS(0) goroutines.
+// Filtering stops at the first error returned by the function, or when the context is done, and that error is returned.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "cherry": 8}
+//	filtered, err := newMap.ParallelFilter(ctx, 4, func(ctx context.Context, key string, value int) (bool, error) {
+//		return value > 4, nil
+//	}) // &map[apple:5 cherry:8], nil
+func (gomap *Map[K, V]) ParallelFilter(ctx context.Context, limit int, fn func(ctx context.Context, key K, value V) (bool, error)) (*Map[K, V], error) {
+	entries := gomap.entries()
+	keep := make([]bool, len(entries))
+	err := parallelChunks(ctx, len(entries), limit, func(ctx context.Context, start int, end int) error {
+		for i := start; i < end; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var err error
+			if keep[i], err = fn(ctx, entries[i].Key, entries[i].Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	newMap := make(Map[K, V])
+	for i, entry := range entries {
+		if keep[i] {
+			newMap.Add(entry.Key, entry.Value)
+		}
+	}
+	return &newMap, nil
+}
+
+// ParallelMap creates a new map with the values returned by the provided function for each key-value pair,
+// calling the function on at most limit goroutines. A limit less than 1 uses runtime.GOMAXPROCS(0) goroutines.
+// Mapping stops at the first error returned by the function, or when the context is done, and that error is returned.
+// The original map remains unchanged.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3}
+//	doubled, err := newMap.ParallelMap(ctx, 4, func(ctx context.Context, key string, value int) (int, error) {
+//		return value * 2, nil
+//	}) // &map[apple:10 banana:6], nil
+func (gomap *Map[K, V]) ParallelMap(ctx context.Context, limit int, fn func(ctx context.Context, key K, value V) (V, error)) (*Map[K, V], error) {
+	entries := gomap.entries()
+	err := parallelChunks(ctx, len(entries), limit, func(ctx context.Context, start int, end int) error {
+		for i := start; i < end; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var err error
+			if entries[i].Value, err = fn(ctx, entries[i].Key, entries[i].Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	newMap := make(Map[K, V], len(entries))
+	for _, entry := range entries {
+		newMap.Add(entry.Key, entry.Value)
+	}
+	return &newMap, nil
+}
+
 // Pop removes a key-value pair from the map based on the provided key and returns the removed value.
 // If the key is found in the gomap, the corresponding value is returned. If the key is not present,
 // the zero value for the value type is returned.
@@ -981,6 +1410,19 @@ func (gomap *Map[K, V]) PopManyFunc(fn func(key K, value V) bool) *slice.Slice[V
 	return &values
 }
 
+// ReadOnly returns a read-only View of the map. The View is backed by the map without copying,
+// so later changes to the map are visible through it, but the View itself cannot modify the map.
+//
+//	// Create a new Map instance.
+//	newMap := make(gomap.Map[string, int])
+//	newMap.Add("apple", 5)
+//	readOnly := newMap.ReadOnly()
+//	newMap.Add("banana", 3)
+//	length := readOnly.Length() // 2
+func (gomap *Map[K, V]) ReadOnly() View[K, V] {
+	return &view[K, V]{gomap: gomap}
+}
+
 // ReplaceMany iterates over the key-value pairs in the map and applies the provided function to each pair.
 // The function can modify the value and return a boolean indicating whether the update should be performed.
 // If the function returns true, the key-value pair is updated in the same map with the modified value.
@@ -1006,6 +1448,116 @@ func (gomap *Map[K, V]) ReplaceMany(fn func(key K, value V) (V, bool)) *Map[K, V
 	return gomap
 }
 
+// ReplaceManyCtx applies the provided function to each key-value pair and updates the pairs for which it returns true.
+// It stops at the first error returned by the function, or when the context is done, and returns the error,
+// in which case the map is left unchanged. The context is checked every 64 pairs.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+//	err := newMap.ReplaceManyCtx(ctx, func(key string, value string) (string, bool, error) {
+//		n, err := strconv.Atoi(value)
+//		return strconv.Itoa(n + 1), true, err
+//	}) // nil, newMap is &map[a:2 b:3]
+func (gomap *Map[K, V]) ReplaceManyCtx(ctx context.Context, fn func(key K, value V) (V, bool, error)) error {
+	updates := make(Map[K, V])
+	err := gomap.EachCtx(ctx, func(key K, value V) error {
+		updatedValue, ok, err := fn(key, value)
+		if ok && err == nil {
+			updates.Add(key, updatedValue)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	gomap.AddMany(updates)
+	return nil
+}
+
+// ReplaceManyErr applies the provided function to each key-value pair and updates the pairs for which it returns true.
+// It stops at the first error returned by the function and returns it, in which case the map is left unchanged.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, string]{"a": "1", "b": "x"}
+//	err := newMap.ReplaceManyErr(func(key string, value string) (string, bool, error) {
+//		n, err := strconv.Atoi(value)
+//		return strconv.Itoa(n + 1), true, err
+//	}) // strconv.Atoi: parsing "x": invalid syntax, newMap is unchanged
+func (gomap *Map[K, V]) ReplaceManyErr(fn func(key K, value V) (V, bool, error)) error {
+	updates := make(Map[K, V])
+	for key, value := range *gomap {
+		updatedValue, ok, err := fn(key, value)
+		if err != nil {
+			return err
+		}
+		if ok {
+			updates.Add(key, updatedValue)
+		}
+	}
+	gomap.AddMany(updates)
+	return nil
+}
+
+// SortedEntries returns a slice containing the key-value pairs of the map in the order defined by the less function.
+// Pairs that less does not order are ordered by their keys, so the result is the same on every run.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "cherry": 8}
+//	entries := newMap.SortedEntries(func(a, b gomap.Entry[string, int]) bool {
+//		return a.Value > b.Value
+//	}) // &[{cherry 8} {apple 5} {banana 3}]
+func (gomap *Map[K, V]) SortedEntries(less func(a Entry[K, V], b Entry[K, V]) bool) *slice.Slice[Entry[K, V]] {
+	entries := slice.Slice[Entry[K, V]](gomap.entries())
+	less = lessThenKey(less)
+	sort.Slice(entries, func(i, j int) bool {
+		return less(entries[i], entries[j])
+	})
+	return &entries
+}
+
+// SortedKeys returns a slice containing the keys of the map in the order defined by the less function.
+// Keys that less does not order, such as keys that differ only in case for a case-insensitive less,
+// are ordered by their own values, so the result is the same on every run.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"cherry": 8, "apple": 5, "banana": 3}
+//	keys := newMap.SortedKeys(func(a, b string) bool {
+//		return a < b
+//	}) // &[apple banana cherry]
+func (gomap *Map[K, V]) SortedKeys(less func(a K, b K) bool) *slice.Slice[K] {
+	keys := make(slice.Slice[K], 0, len(*gomap))
+	for key := range *gomap {
+		keys = append(keys, key)
+	}
+	compare := keyOrder[K]()
+	sort.Slice(keys, func(i, j int) bool {
+		if less(keys[i], keys[j]) {
+			return true
+		}
+		return !less(keys[j], keys[i]) && compare(keys[i], keys[j]) < 0
+	})
+	return &keys
+}
+
+// SortedValues returns a slice containing the values of the map in the order defined by the less function.
+// Values that less does not order are ordered by their keys, so the result is the same on every run.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "cherry": 8}
+//	values := newMap.SortedValues(func(a, b int) bool {
+//		return a < b
+//	}) // &[3 5 8]
+func (gomap *Map[K, V]) SortedValues(less func(a V, b V) bool) *slice.Slice[V] {
+	entries := gomap.SortedEntries(func(a Entry[K, V], b Entry[K, V]) bool {
+		return less(a.Value, b.Value)
+	})
+	values := make(slice.Slice[V], len(*entries))
+	for i, entry := range *entries {
+		values[i] = entry.Value
+	}
+	return &values
+}
+
 // TakeFrom transfers all key-value pairs from another map into the current gomap, emptying the other map.
 // It takes another map as input and adds all key-value pairs from the other map to the current map.
 //
@@ -1024,6 +1576,94 @@ func (gomap *Map[K, V]) TakeFrom(other *Map[K, V]) *Map[K, V] {
 	return gomap
 }
 
+// TopN returns a slice containing the first n key-value pairs of the map in the order defined by the less function,
+// without sorting the whole map. If n is greater than the length of the map, all pairs are returned.
+// Pairs that less does not order are ordered by their keys, as in SortedEntries, so the same pairs are returned
+// in the same order on every run.
+//
+//	// Create a new Map instance.
+//	scores := &gomap.Map[string, int]{"ada": 90, "bob": 72, "cy": 85}
+//	top := scores.TopN(2, func(a, b gomap.Entry[string, int]) bool {
+//		return a.Value > b.Value
+//	}) // &[{ada 90} {cy 85}]
+func (gomap *Map[K, V]) TopN(n int, less func(a Entry[K, V], b Entry[K, V]) bool) *slice.Slice[Entry[K, V]] {
+	top := make(slice.Slice[Entry[K, V]], 0, max(0, min(n, len(*gomap))))
+	if n <= 0 {
+		return &top
+	}
+	less = lessThenKey(less)
+	// top is kept as a heap whose root is the last pair in order, so that it can be replaced by a pair before it.
+	after := func(i, j int) bool {
+		return less(top[j], top[i])
+	}
+	down := func(i int) {
+		for {
+			child := 2*i + 1
+			if child >= len(top) {
+				return
+			}
+			if child+1 < len(top) && after(child+1, child) {
+				child++
+			}
+			if !after(child, i) {
+				return
+			}
+			top[i], top[child] = top[child], top[i]
+			i = child
+		}
+	}
+	for key, value := range *gomap {
+		entry := Entry[K, V]{Key: key, Value: value}
+		switch {
+		case len(top) < n:
+			top = append(top, entry)
+			for i := len(top) - 1; i > 0 && after(i, (i-1)/2); i = (i - 1) / 2 {
+				top[i], top[(i-1)/2] = top[(i-1)/2], top[i]
+			}
+		case less(entry, top[0]):
+			top[0] = entry
+			down(0)
+		}
+	}
+	sort.Slice(top, func(i, j int) bool {
+		return less(top[i], top[j])
+	})
+	return &top
+}
+
+// Unzip returns slices containing the keys and values of the map, where each value is at the same index as its key.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3}
+//	keys, values := newMap.Unzip() // &[apple banana], &[5 3] in any order
+func (gomap *Map[K, V]) Unzip() (*slice.Slice[K], *slice.Slice[V]) {
+	keys := make(slice.Slice[K], 0, len(*gomap))
+	values := make(slice.Slice[V], 0, len(*gomap))
+	for key, value := range *gomap {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return &keys, &values
+}
+
+// Upsert inserts the provided value if the key does not exist, or replaces the existing value with the result
+// of calling the function with it. It returns the value now associated with the key.
+//
+//	// Create a new Map instance.
+//	newMap := make(gomap.Map[string, int])
+//	increment := func(value int) int {
+//		return value + 1
+//	}
+//	value := newMap.Upsert("apple", 1, increment) // 1
+//	value = newMap.Upsert("apple", 1, increment)  // 2
+func (gomap *Map[K, V]) Upsert(key K, value V, fn func(value V) V) V {
+	if existingValue, ok := gomap.Get(key); ok {
+		value = fn(existingValue)
+	}
+	gomap.Add(key, value)
+	return value
+}
+
 // Values returns a slice containing all the values present in the map.
 // It iterates over the map and collects all the values in the order of insertion.
 //
@@ -1,7 +1,11 @@
 package gomap_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/rand"
 	"reflect"
 	"sort"
 	"strconv"
@@ -323,6 +327,77 @@ func TestAddValuesFunc(t *testing.T) {
 	}
 }
 
+// TestCompute tests Map.Compute.
+func TestCompute(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5}
+
+	// Test case 1: Compute a new value for an existing key.
+	value, ok := newMap.Compute("apple", func(value int, ok bool) (int, bool) {
+		if !ok {
+			t.Errorf("Expected key 'apple' to exist")
+		}
+		return value + 1, true
+	})
+	if !ok || value != 6 || newMap.Fetch("apple") != 6 {
+		t.Errorf("Expected key 'apple' to have value 6, but got %v", value)
+	}
+
+	// Test case 2: Compute a value for a missing key.
+	value, ok = newMap.Compute("banana", func(value int, ok bool) (int, bool) {
+		if ok || value != 0 {
+			t.Errorf("Expected key 'banana' to be missing with a zero value")
+		}
+		return 3, true
+	})
+	if !ok || value != 3 || newMap.Fetch("banana") != 3 {
+		t.Errorf("Expected key 'banana' to have value 3, but got %v", value)
+	}
+
+	// Test case 3: Returning false deletes the key.
+	value, ok = newMap.Compute("apple", func(value int, ok bool) (int, bool) {
+		return value, false
+	})
+	if ok || value != 0 || newMap.Has("apple") {
+		t.Errorf("Expected key 'apple' to be deleted")
+	}
+}
+
+// TestComputeIfAbsent tests Map.ComputeIfAbsent.
+func TestComputeIfAbsent(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5}
+	calls := 0
+	fn := func(key string) int {
+		calls++
+		return len(key)
+	}
+	if value := newMap.ComputeIfAbsent("apple", fn); value != 5 || calls != 0 {
+		t.Errorf("Expected existing value 5 without calling the function, but got %v", value)
+	}
+	if value := newMap.ComputeIfAbsent("banana", fn); value != 6 || calls != 1 || newMap.Fetch("banana") != 6 {
+		t.Errorf("Expected computed value 6 to be stored, but got %v", value)
+	}
+}
+
+// TestComputeIfPresent tests Map.ComputeIfPresent.
+func TestComputeIfPresent(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3}
+	double := func(key string, value int) (int, bool) {
+		return value * 2, true
+	}
+	if value, ok := newMap.ComputeIfPresent("apple", double); !ok || value != 10 || newMap.Fetch("apple") != 10 {
+		t.Errorf("Expected key 'apple' to have value 10, but got %v", value)
+	}
+	if value, ok := newMap.ComputeIfPresent("cherry", double); ok || value != 0 || newMap.Has("cherry") {
+		t.Errorf("Expected key 'cherry' to remain missing")
+	}
+	newMap.ComputeIfPresent("banana", func(key string, value int) (int, bool) {
+		return value, false
+	})
+	if newMap.Has("banana") {
+		t.Errorf("Expected key 'banana' to be deleted")
+	}
+}
+
 // TestContains tests Map.Contains.
 func TestContains(t *testing.T) {
 	// Test case 1: Check for a value in an empty gomap.
@@ -357,6 +432,23 @@ func TestContains(t *testing.T) {
 	}
 }
 
+// TestContainsFirst tests Map.ContainsFirst.
+func TestContainsFirst(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"orange": 5, "apple": 5, "banana": 10, "cherry": 5}
+	less := func(a, b string) bool { return a < b }
+	for i := 0; i < 10; i++ {
+		if key, found := newMap.ContainsFirst(5, less); key != "apple" || !found {
+			t.Fatalf("Expected ('apple', true), but got (%s, %t)", key, found)
+		}
+	}
+	if key, found := newMap.ContainsFirst(5, func(a, b string) bool { return a > b }); key != "orange" || !found {
+		t.Errorf("Expected ('orange', true), but got (%s, %t)", key, found)
+	}
+	if key, found := newMap.ContainsFirst(7, less); key != "" || found {
+		t.Errorf("Expected ('', false), but got (%s, %t)", key, found)
+	}
+}
+
 // TestDelete tests Map.Delete.
 func TestDelete(t *testing.T) {
 	newMap := make(gomap.Map[string, int])
@@ -577,6 +669,82 @@ func TestEachBreak(t *testing.T) {
 	}
 }
 
+// TestEachByKeys tests Map.EachByKeys.
+func TestEachByKeys(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "cherry": 8}
+	var visited []string
+	newMap.EachByKeys([]string{"cherry", "kiwi", "apple"}, func(key string, value int) {
+		visited = append(visited, fmt.Sprintf("%s:%d", key, value))
+	})
+	if expected := []string{"cherry:8", "apple:5"}; !reflect.DeepEqual(visited, expected) {
+		t.Errorf("Expected %v, but got %v", expected, visited)
+	}
+}
+
+// TestEachCtx tests Map.EachCtx.
+func TestEachCtx(t *testing.T) {
+	newMap := &gomap.Map[int, int]{}
+	for i := 0; i < 1000; i++ {
+		newMap.Add(i, i)
+	}
+
+	// Test case 1: Iteration visits every pair when the context is not done.
+	count := 0
+	err := newMap.EachCtx(context.Background(), func(key int, value int) error {
+		count++
+		return nil
+	})
+	if err != nil || count != newMap.Length() {
+		t.Errorf("Expected %d pairs to be visited, but got %d, %v", newMap.Length(), count, err)
+	}
+
+	// Test case 2: Iteration stops soon after the context is cancelled.
+	ctx, cancel := context.WithCancel(context.Background())
+	count = 0
+	err = newMap.EachCtx(ctx, func(key int, value int) error {
+		count++
+		if count == 10 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+	if count >= newMap.Length() {
+		t.Errorf("Expected iteration to stop early, but %d pairs were visited", count)
+	}
+
+	// Test case 3: Errors returned by the function stop iteration.
+	failure := errors.New("failure")
+	if err := newMap.EachCtx(context.Background(), func(key int, value int) error { return failure }); !errors.Is(err, failure) {
+		t.Errorf("Expected the error returned by the function, but got %v", err)
+	}
+}
+
+// TestEachErr tests Map.EachErr.
+func TestEachErr(t *testing.T) {
+	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+	sum := 0
+	err := newMap.EachErr(func(key string, value string) error {
+		n, err := strconv.Atoi(value)
+		sum += n
+		return err
+	})
+	if err != nil || sum != 3 {
+		t.Errorf("Expected 3, but got %d, %v", sum, err)
+	}
+
+	newMap.Add("c", "x")
+	err = newMap.EachErr(func(key string, value string) error {
+		_, err := strconv.Atoi(value)
+		return err
+	})
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected strconv.ErrSyntax, but got %v", err)
+	}
+}
+
 // TestEachKey tests Map.EachKey.
 func TestEachKey(t *testing.T) {
 	newMap := make(gomap.Map[string, int])
@@ -627,6 +795,33 @@ func TestEachKeyBreak(t *testing.T) {
 	}
 }
 
+// TestEachSorted tests Map.EachSorted.
+func TestEachSorted(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"cherry": 8, "apple": 5, "banana": 3, "date": 1}
+	var visited []string
+	newMap.EachSorted(func(a, b string) bool { return a < b }, func(key string, value int) {
+		visited = append(visited, key)
+	})
+	if expected := []string{"apple", "banana", "cherry", "date"}; !reflect.DeepEqual(visited, expected) {
+		t.Errorf("Expected %v, but got %v", expected, visited)
+	}
+}
+
+// TestEachSortedBreak tests Map.EachSortedBreak.
+func TestEachSortedBreak(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"cherry": 8, "apple": 5, "banana": 3, "date": 1}
+	for i := 0; i < 10; i++ {
+		var visited []string
+		newMap.EachSortedBreak(func(a, b string) bool { return a < b }, func(key string, value int) bool {
+			visited = append(visited, key)
+			return key != "banana"
+		})
+		if expected := []string{"apple", "banana"}; !reflect.DeepEqual(visited, expected) {
+			t.Fatalf("Expected %v, but got %v", expected, visited)
+		}
+	}
+}
+
 // TestEachValue tests Map.EachValue.
 func TestEachValue(t *testing.T) {
 	// Create a new gomap.
@@ -743,6 +938,23 @@ func TestEmptyInto(t *testing.T) {
 	}
 }
 
+// TestEntries tests Map.Entries.
+func TestEntries(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3}
+	entries := newMap.Entries()
+	if entries.Length() != 2 {
+		t.Fatalf("Expected 2 entries, but got %v", entries)
+	}
+	for _, entry := range *entries {
+		if value, ok := newMap.Get(entry.Key); !ok || value != entry.Value {
+			t.Errorf("Expected entry %v to match the map, but got (%d, %t)", entry, value, ok)
+		}
+	}
+	if entries := (&gomap.Map[string, int]{}).Entries(); entries.Length() != 0 {
+		t.Errorf("Expected no entries, but got %v", entries)
+	}
+}
+
 // TestEqual tests Map.Equal.
 func TestEqual(t *testing.T) {
 	// Test case 1: Compare equal hasnewMapables.
@@ -928,6 +1140,57 @@ func TestFilter(t *testing.T) {
 	}
 }
 
+// TestFilterCtx tests Map.FilterCtx.
+func TestFilterCtx(t *testing.T) {
+	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+	filtered, err := newMap.FilterCtx(context.Background(), func(key string, value string) (bool, error) {
+		n, err := strconv.Atoi(value)
+		return n > 1, err
+	})
+	expected := &gomap.Map[string, string]{"b": "2"}
+	if err != nil || !filtered.Equal(expected) {
+		t.Errorf("Expected %v, but got %v, %v", expected, filtered, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	filtered, err = newMap.FilterCtx(ctx, func(key string, value string) (bool, error) { return true, nil })
+	if !errors.Is(err, context.Canceled) || filtered != nil {
+		t.Errorf("Expected context.Canceled and a nil map, but got %v, %v", filtered, err)
+	}
+
+	newMap.Add("c", "x")
+	filtered, err = newMap.FilterCtx(context.Background(), func(key string, value string) (bool, error) {
+		_, err := strconv.Atoi(value)
+		return true, err
+	})
+	if err == nil || filtered != nil {
+		t.Errorf("Expected an error and a nil map, but got %v, %v", filtered, err)
+	}
+}
+
+// TestFilterErr tests Map.FilterErr.
+func TestFilterErr(t *testing.T) {
+	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+	filtered, err := newMap.FilterErr(func(key string, value string) (bool, error) {
+		n, err := strconv.Atoi(value)
+		return n > 1, err
+	})
+	expected := &gomap.Map[string, string]{"b": "2"}
+	if err != nil || !filtered.Equal(expected) {
+		t.Errorf("Expected %v, but got %v, %v", expected, filtered, err)
+	}
+
+	newMap.Add("c", "x")
+	filtered, err = newMap.FilterErr(func(key string, value string) (bool, error) {
+		_, err := strconv.Atoi(value)
+		return true, err
+	})
+	if err == nil || filtered != nil {
+		t.Errorf("Expected an error and a nil map, but got %v, %v", filtered, err)
+	}
+}
+
 // TestGet tests Map.Get.
 
 func TestGet(t *testing.T) {
@@ -1208,6 +1471,60 @@ func TestMapBreak(t *testing.T) {
 	}
 }
 
+// TestMapCtx tests Map.MapCtx.
+func TestMapCtx(t *testing.T) {
+	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+	mapped, err := newMap.MapCtx(context.Background(), func(key string, value string) (string, error) {
+		n, err := strconv.Atoi(value)
+		return strconv.Itoa(n * 2), err
+	})
+	expected := &gomap.Map[string, string]{"a": "2", "b": "4"}
+	if err != nil || !mapped.Equal(expected) {
+		t.Errorf("Expected %v, but got %v, %v", expected, mapped, err)
+	}
+	if newMap.Fetch("a") != "1" {
+		t.Errorf("Expected the original map to be unchanged")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if mapped, err := newMap.MapCtx(ctx, func(key string, value string) (string, error) { return value, nil }); !errors.Is(err, context.Canceled) || mapped != nil {
+		t.Errorf("Expected context.Canceled and a nil map, but got %v, %v", mapped, err)
+	}
+
+	newMap.Add("c", "x")
+	if mapped, err := newMap.MapCtx(context.Background(), func(key string, value string) (string, error) {
+		_, err := strconv.Atoi(value)
+		return value, err
+	}); err == nil || mapped != nil {
+		t.Errorf("Expected an error and a nil map, but got %v, %v", mapped, err)
+	}
+}
+
+// TestMapErr tests Map.MapErr.
+func TestMapErr(t *testing.T) {
+	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+	mapped, err := newMap.MapErr(func(key string, value string) (string, error) {
+		n, err := strconv.Atoi(value)
+		return strconv.Itoa(n * 2), err
+	})
+	expected := &gomap.Map[string, string]{"a": "2", "b": "4"}
+	if err != nil || !mapped.Equal(expected) {
+		t.Errorf("Expected %v, but got %v, %v", expected, mapped, err)
+	}
+	if newMap.Fetch("a") != "1" {
+		t.Errorf("Expected the original map to be unchanged")
+	}
+
+	newMap.Add("c", "x")
+	if mapped, err := newMap.MapErr(func(key string, value string) (string, error) {
+		_, err := strconv.Atoi(value)
+		return value, err
+	}); err == nil || mapped != nil {
+		t.Errorf("Expected an error and a nil map, but got %v, %v", mapped, err)
+	}
+}
+
 // TestMerge tests Map.Merge.
 func TestMerge(t *testing.T) {
 	// Test case: Merge all key-value pairs from another gomap.
@@ -1590,6 +1907,134 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+// TestReplaceManyCtx tests Map.ReplaceManyCtx.
+func TestReplaceManyCtx(t *testing.T) {
+	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+	err := newMap.ReplaceManyCtx(context.Background(), func(key string, value string) (string, bool, error) {
+		n, err := strconv.Atoi(value)
+		return strconv.Itoa(n + 1), key == "a", err
+	})
+	expected := &gomap.Map[string, string]{"a": "2", "b": "2"}
+	if err != nil || !newMap.Equal(expected) {
+		t.Errorf("Expected %v, but got %v, %v", expected, newMap, err)
+	}
+
+	// The map is unchanged when the context is done or the function returns an error.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = newMap.ReplaceManyCtx(ctx, func(key string, value string) (string, bool, error) { return "", true, nil })
+	if !errors.Is(err, context.Canceled) || !newMap.Equal(expected) {
+		t.Errorf("Expected %v and context.Canceled, but got %v, %v", expected, newMap, err)
+	}
+	newMap.Add("c", "x")
+	err = newMap.ReplaceManyCtx(context.Background(), func(key string, value string) (string, bool, error) {
+		n, err := strconv.Atoi(value)
+		return strconv.Itoa(n + 1), true, err
+	})
+	expected = &gomap.Map[string, string]{"a": "2", "b": "2", "c": "x"}
+	if err == nil || !newMap.Equal(expected) {
+		t.Errorf("Expected %v and an error, but got %v, %v", expected, newMap, err)
+	}
+}
+
+// TestReplaceManyErr tests Map.ReplaceManyErr.
+func TestReplaceManyErr(t *testing.T) {
+	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+	err := newMap.ReplaceManyErr(func(key string, value string) (string, bool, error) {
+		n, err := strconv.Atoi(value)
+		return strconv.Itoa(n + 1), key == "a", err
+	})
+	expected := &gomap.Map[string, string]{"a": "2", "b": "2"}
+	if err != nil || !newMap.Equal(expected) {
+		t.Errorf("Expected %v, but got %v, %v", expected, newMap, err)
+	}
+
+	// The map is unchanged when the function returns an error.
+	newMap.Add("c", "x")
+	err = newMap.ReplaceManyErr(func(key string, value string) (string, bool, error) {
+		n, err := strconv.Atoi(value)
+		return strconv.Itoa(n + 1), true, err
+	})
+	expected = &gomap.Map[string, string]{"a": "2", "b": "2", "c": "x"}
+	if err == nil || !newMap.Equal(expected) {
+		t.Errorf("Expected %v and an error, but got %v, %v", expected, newMap, err)
+	}
+}
+
+// TestSortedEntries tests Map.SortedEntries.
+func TestSortedEntries(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "cherry": 8}
+	entries := newMap.SortedEntries(func(a, b gomap.Entry[string, int]) bool {
+		return a.Value > b.Value
+	})
+	expected := []gomap.Entry[string, int]{{Key: "cherry", Value: 8}, {Key: "apple", Value: 5}, {Key: "banana", Value: 3}}
+	if !reflect.DeepEqual([]gomap.Entry[string, int](*entries), expected) {
+		t.Errorf("Expected %v, but got %v", expected, entries)
+	}
+}
+
+// TestSortedKeys tests Map.SortedKeys.
+func TestSortedKeys(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"cherry": 8, "apple": 5, "banana": 3}
+	keys := newMap.SortedKeys(func(a, b string) bool { return a < b })
+	if expected := []string{"apple", "banana", "cherry"}; !reflect.DeepEqual([]string(*keys), expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+	if keys := (&gomap.Map[string, int]{}).SortedKeys(func(a, b string) bool { return a < b }); keys.Length() != 0 {
+		t.Errorf("Expected no keys, but got %v", keys)
+	}
+}
+
+// TestSortedValues tests Map.SortedValues.
+func TestSortedValues(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "cherry": 8, "date": 3}
+	values := newMap.SortedValues(func(a, b int) bool { return a < b })
+	if expected := []int{3, 3, 5, 8}; !reflect.DeepEqual([]int(*values), expected) {
+		t.Errorf("Expected %v, but got %v", expected, values)
+	}
+}
+
+// TestSortedTies tests that SortedEntries, SortedKeys, SortedValues and EachSorted break ties by key.
+func TestSortedTies(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+	type item struct {
+		Name  string
+		Price int
+	}
+	for i := 0; i < 20; i++ {
+		words := &gomap.Map[string, int]{"b": 1, "B": 2, "a": 3, "A": 4}
+		keys := words.SortedKeys(func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) })
+		if expected := []string{"A", "a", "B", "b"}; !reflect.DeepEqual([]string(*keys), expected) {
+			t.Fatalf("Expected %v, but got %v", expected, keys)
+		}
+		var visited []string
+		words.EachSorted(func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) }, func(key string, _ int) {
+			visited = append(visited, key)
+		})
+		if expected := []string{"A", "a", "B", "b"}; !reflect.DeepEqual(visited, expected) {
+			t.Fatalf("Expected %v, but got %v", expected, visited)
+		}
+		items := &gomap.Map[string, item]{"c": {"pear", 2}, "a": {"plum", 2}, "b": {"fig", 1}}
+		values := items.SortedValues(func(a, b item) bool { return a.Price < b.Price })
+		if expected := []item{{"fig", 1}, {"plum", 2}, {"pear", 2}}; !reflect.DeepEqual([]item(*values), expected) {
+			t.Fatalf("Expected %v, but got %v", expected, values)
+		}
+		points := &gomap.Map[point, int]{{1, 2}: 0, {0, 5}: 0, {1, 1}: 0, {0, 0}: 1}
+		entries := points.SortedEntries(func(a, b gomap.Entry[point, int]) bool { return a.Value > b.Value })
+		expected := []gomap.Entry[point, int]{{point{0, 0}, 1}, {point{0, 5}, 0}, {point{1, 1}, 0}, {point{1, 2}, 0}}
+		if !reflect.DeepEqual([]gomap.Entry[point, int](*entries), expected) {
+			t.Fatalf("Expected %v, but got %v", expected, entries)
+		}
+		mixed := &gomap.Map[any, int]{"b": 0, 2: 0, "a": 0, 1: 0, nil: 0}
+		anyKeys := mixed.SortedKeys(func(a, b any) bool { return false })
+		if expected := []any{nil, 1, 2, "a", "b"}; !reflect.DeepEqual([]any(*anyKeys), expected) {
+			t.Fatalf("Expected %v, but got %v", expected, anyKeys)
+		}
+	}
+}
+
 // TestTakeFrom tests Map.TakeFrom.
 func TestTakeFrom(t *testing.T) {
 	// Test case 1: Transfer from an empty gomap to another empty gomap.
@@ -1626,6 +2071,73 @@ func TestTakeFrom(t *testing.T) {
 	}
 }
 
+// TestTopN tests Map.TopN.
+func TestTopN(t *testing.T) {
+	scores := &gomap.Map[string, int]{"ada": 90, "bob": 72, "cy": 85, "dee": 60}
+	greater := func(a, b gomap.Entry[string, int]) bool {
+		return a.Value > b.Value
+	}
+	expected := []gomap.Entry[string, int]{{Key: "ada", Value: 90}, {Key: "cy", Value: 85}}
+	if top := scores.TopN(2, greater); !reflect.DeepEqual([]gomap.Entry[string, int](*top), expected) {
+		t.Errorf("Expected %v, but got %v", expected, top)
+	}
+	if top := scores.TopN(10, greater); top.Length() != 4 || (*top)[3].Key != "dee" {
+		t.Errorf("Expected all 4 entries in order, but got %v", top)
+	}
+	if top := scores.TopN(0, greater); top.Length() != 0 {
+		t.Errorf("Expected no entries, but got %v", top)
+	}
+	ties := &gomap.Map[string, int]{"eve": 90, "bob": 90, "cy": 85, "ada": 90, "dee": 90}
+	expected = []gomap.Entry[string, int]{{Key: "ada", Value: 90}, {Key: "bob", Value: 90}}
+	for i := 0; i < 20; i++ {
+		if top := ties.TopN(2, greater); !reflect.DeepEqual([]gomap.Entry[string, int](*top), expected) {
+			t.Fatalf("Expected ties to be broken by key as %v, but got %v", expected, top)
+		}
+	}
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		newMap := make(gomap.Map[int, int])
+		for j := random.Intn(50); j > 0; j-- {
+			newMap.Add(random.Intn(100), random.Intn(20))
+		}
+		n := random.Intn(10)
+		less := func(a, b gomap.Entry[int, int]) bool {
+			return a.Value < b.Value
+		}
+		sorted := *newMap.SortedEntries(less)
+		expected := []gomap.Entry[int, int](sorted[:min(n, len(sorted))])
+		if top := newMap.TopN(n, less); !reflect.DeepEqual([]gomap.Entry[int, int](*top), expected) {
+			t.Fatalf("Expected TopN(%d) to be %v, but got %v", n, expected, top)
+		}
+	}
+}
+
+// TestUnzip tests Map.Unzip.
+func TestUnzip(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "cherry": 8}
+	keys, values := newMap.Unzip()
+	if keys.Length() != 3 || values.Length() != 3 {
+		t.Fatalf("Expected 3 keys and values, but got %v and %v", keys, values)
+	}
+	if zipped := gomap.Zip(*keys, *values); !reflect.DeepEqual(zipped, newMap) {
+		t.Errorf("Expected %v, but got %v", newMap, zipped)
+	}
+}
+
+// TestUpsert tests Map.Upsert.
+func TestUpsert(t *testing.T) {
+	newMap := &gomap.Map[string, int]{}
+	increment := func(value int) int {
+		return value + 1
+	}
+	if value := newMap.Upsert("apple", 1, increment); value != 1 {
+		t.Errorf("Expected inserted value 1, but got %v", value)
+	}
+	if value := newMap.Upsert("apple", 1, increment); value != 2 || newMap.Fetch("apple") != 2 {
+		t.Errorf("Expected updated value 2, but got %v", value)
+	}
+}
+
 // TestValues tests Map.Values.
 func TestValues(t *testing.T) {
 	// Test case 1: Values of an empty gomap should be an empty slice.
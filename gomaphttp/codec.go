@@ -0,0 +1,88 @@
+package gomaphttp
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// KeyCodec converts map keys to and from the strings used in request paths and listings.
+type KeyCodec[K any] interface {
+	// FormatKey returns the string form of the key.
+	FormatKey(key K) string
+	// ParseKey parses the string form of a key.
+	ParseKey(s string) (K, error)
+}
+
+// ValueCodec converts map values to and from request and response bodies.
+type ValueCodec[V any] interface {
+	// ContentType returns the media type of encoded values.
+	ContentType() string
+	// Decode parses an encoded value.
+	Decode(data []byte) (V, error)
+	// Encode returns the encoded value.
+	Encode(value V) ([]byte, error)
+}
+
+// StringKeys is a KeyCodec for string keys.
+type StringKeys struct{}
+
+// FormatKey returns the key.
+func (StringKeys) FormatKey(key string) string {
+	return key
+}
+
+// ParseKey returns the string.
+func (StringKeys) ParseKey(s string) (string, error) {
+	return s, nil
+}
+
+// IntKeys is a KeyCodec for int keys written in base 10.
+type IntKeys struct{}
+
+// FormatKey returns the key in base 10.
+func (IntKeys) FormatKey(key int) string {
+	return strconv.Itoa(key)
+}
+
+// ParseKey parses a base 10 integer.
+func (IntKeys) ParseKey(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+// JSONValues is a ValueCodec that encodes values as JSON.
+type JSONValues[V any] struct{}
+
+// ContentType returns "application/json".
+func (JSONValues[V]) ContentType() string {
+	return "application/json"
+}
+
+// Decode parses the JSON encoding of a value.
+func (JSONValues[V]) Decode(data []byte) (V, error) {
+	var value V
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// Encode returns the JSON encoding of the value.
+func (JSONValues[V]) Encode(value V) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// TextValues is a ValueCodec that stores string values as plain text.
+type TextValues struct{}
+
+// ContentType returns "text/plain; charset=utf-8".
+func (TextValues) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+// Decode returns the body as a string.
+func (TextValues) Decode(data []byte) (string, error) {
+	return string(data), nil
+}
+
+// Encode returns the string as bytes.
+func (TextValues) Encode(value string) ([]byte, error) {
+	return []byte(value), nil
+}
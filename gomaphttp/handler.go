@@ -0,0 +1,327 @@
+// Package gomaphttp serves a gomap.Map as a key-value HTTP endpoint.
+//
+// A Handler serves the following requests, relative to the path it is mounted at:
+//
+//	GET    /         lists keys in sorted order, filtered by the prefix, limit and cursor query parameters
+//	PATCH  /         adds and deletes many keys at once
+//	GET    /{key}    returns the value of the key with its ETag
+//	PUT    /{key}    stores the request body as the value of the key
+//	DELETE /{key}    deletes the key
+//
+// Keys in paths are escaped using URL path escaping, so keys may contain slashes written as %2F.
+// PUT and DELETE honour If-Match and If-None-Match headers, and GET honours If-None-Match,
+// so that clients can make conditional updates using the ETags returned by the handler.
+package gomaphttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+const (
+	// DefaultLimit is the number of keys listed when the request does not set a limit.
+	DefaultLimit = 100
+	// MaxLimit is the largest number of keys listed in one response.
+	MaxLimit = 1000
+	// MaxBodySize is the largest request body the handler reads.
+	MaxBodySize = 10 << 20
+)
+
+// List is the response to a GET request for the root of the handler.
+// NextCursor is empty when there are no more keys to list.
+type List struct {
+	Keys       []string `json:"keys"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
+
+// Patch is the body of a PATCH request. Keys in Set are added or updated and keys in Delete are removed,
+// with deletions applied after additions. Values in Set are decoded by the ValueCodec of the handler:
+// they are passed as-is to codecs whose content type is application/json, and must otherwise be JSON strings
+// holding the encoded value.
+type Patch struct {
+	Delete []string                   `json:"delete,omitempty"`
+	Set    map[string]json.RawMessage `json:"set,omitempty"`
+}
+
+// Handler is an http.Handler that serves a Map. It is safe for concurrent requests; code that accesses
+// the Map while the handler is serving must use Update and View.
+type Handler[K comparable, V any] struct {
+	gomap  *gomap.Map[K, V]
+	keys   KeyCodec[K]
+	mutex  sync.RWMutex
+	values ValueCodec[V]
+}
+
+// NewHandler creates a Handler serving the map using the provided codecs.
+//
+//	// Serve a new Map instance.
+//	newMap := &gomap.Map[string, Config]{}
+//	handler := gomaphttp.NewHandler(newMap, gomaphttp.StringKeys{}, gomaphttp.JSONValues[Config]{})
+//	http.Handle("/configs/", http.StripPrefix("/configs", handler))
+func NewHandler[K comparable, V any](gomap *gomap.Map[K, V], keys KeyCodec[K], values ValueCodec[V]) *Handler[K, V] {
+	return &Handler[K, V]{gomap: gomap, keys: keys, values: values}
+}
+
+// Update calls fn with the map while holding the handler's write lock.
+func (handler *Handler[K, V]) Update(fn func(gomap *gomap.Map[K, V])) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	fn(handler.gomap)
+}
+
+// View calls fn with the map while holding the handler's read lock. fn must not modify the map.
+func (handler *Handler[K, V]) View(fn func(gomap *gomap.Map[K, V])) {
+	handler.mutex.RLock()
+	defer handler.mutex.RUnlock()
+	fn(handler.gomap)
+}
+
+// ServeHTTP routes the request by its method and whether its path names a key.
+func (handler *Handler[K, V]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/"))
+	if err != nil {
+		http.Error(w, "invalid key", http.StatusBadRequest)
+		return
+	}
+	if path == "" {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			handler.list(w, r)
+		case http.MethodPatch:
+			handler.patch(w, r)
+		default:
+			w.Header().Set("Allow", "GET, HEAD, PATCH")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		}
+		return
+	}
+	key, err := handler.keys.ParseKey(path)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid key: %v", err), http.StatusBadRequest)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		handler.get(w, r, key)
+	case http.MethodPut:
+		handler.put(w, r, key)
+	case http.MethodDelete:
+		handler.delete(w, r, key)
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT, DELETE")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// etag returns the strong ETag of an encoded value.
+func etag(data []byte) string {
+	hash := fnv.New64a()
+	hash.Write(data)
+	return fmt.Sprintf(`"%016x"`, hash.Sum64())
+}
+
+// current returns the encoded value of the key and its ETag. The caller must hold the lock.
+func (handler *Handler[K, V]) current(key K) ([]byte, string, bool, error) {
+	value, ok := handler.gomap.Get(key)
+	if !ok {
+		return nil, "", false, nil
+	}
+	data, err := handler.values.Encode(value)
+	if err != nil {
+		return nil, "", true, err
+	}
+	return data, etag(data), true, nil
+}
+
+// matchETag checks if the ETag is listed in the If-Match or If-None-Match header value.
+func matchETag(header string, tag string, exists bool) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if exists && (candidate == "*" || candidate == tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// precondition checks the If-Match and If-None-Match headers of a request that modifies the key.
+func precondition(r *http.Request, tag string, exists bool) bool {
+	if header := r.Header.Get("If-Match"); header != "" && !matchETag(header, tag, exists) {
+		return false
+	}
+	if header := r.Header.Get("If-None-Match"); header != "" && matchETag(header, tag, exists) {
+		return false
+	}
+	return true
+}
+
+// get writes the value of the key.
+func (handler *Handler[K, V]) get(w http.ResponseWriter, r *http.Request, key K) {
+	handler.mutex.RLock()
+	data, tag, ok, err := handler.current(key)
+	handler.mutex.RUnlock()
+	switch {
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	case !ok:
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	w.Header().Set("ETag", tag)
+	if header := r.Header.Get("If-None-Match"); header != "" && matchETag(header, tag, true) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", handler.values.ContentType())
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	if r.Method != http.MethodHead {
+		w.Write(data)
+	}
+}
+
+// put stores the request body as the value of the key.
+func (handler *Handler[K, V]) put(w http.ResponseWriter, r *http.Request, key K) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	value, err := handler.values.Decode(body)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid value: %v", err), http.StatusBadRequest)
+		return
+	}
+	data, err := handler.values.Encode(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	_, tag, exists, err := handler.current(key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !precondition(r, tag, exists) {
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+		return
+	}
+	handler.gomap.Add(key, value)
+	w.Header().Set("ETag", etag(data))
+	if exists {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// delete removes the key.
+func (handler *Handler[K, V]) delete(w http.ResponseWriter, r *http.Request, key K) {
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	_, tag, exists, err := handler.current(key)
+	switch {
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	case !precondition(r, tag, exists):
+		http.Error(w, http.StatusText(http.StatusPreconditionFailed), http.StatusPreconditionFailed)
+	case !exists:
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+	default:
+		handler.gomap.Delete(key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// list writes the sorted keys that start with the prefix and follow the cursor.
+func (handler *Handler[K, V]) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	prefix, cursor := query.Get("prefix"), query.Get("cursor")
+	limit := DefaultLimit
+	if s := query.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, MaxLimit)
+	}
+	var keys []string
+	handler.mutex.RLock()
+	handler.gomap.EachKey(func(key K) {
+		if s := handler.keys.FormatKey(key); strings.HasPrefix(s, prefix) && s > cursor {
+			keys = append(keys, s)
+		}
+	})
+	handler.mutex.RUnlock()
+	sort.Strings(keys)
+	list := List{Keys: keys}
+	if len(keys) > limit {
+		list.Keys = keys[:limit]
+		list.NextCursor = keys[limit-1]
+	}
+	if list.Keys == nil {
+		list.Keys = []string{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// patch adds and deletes many keys in one request. Nothing is changed if any key or value is invalid.
+func (handler *Handler[K, V]) patch(w http.ResponseWriter, r *http.Request) {
+	var patch Patch
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patch); err != nil {
+		http.Error(w, fmt.Sprintf("invalid patch: %v", err), http.StatusBadRequest)
+		return
+	}
+	additions := make(map[K]V, len(patch.Set))
+	for s, raw := range patch.Set {
+		key, err := handler.keys.ParseKey(s)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid key %q: %v", s, err), http.StatusBadRequest)
+			return
+		}
+		data := []byte(raw)
+		if !strings.HasPrefix(handler.values.ContentType(), "application/json") {
+			var text string
+			if err := json.Unmarshal(raw, &text); err != nil {
+				http.Error(w, fmt.Sprintf("invalid value for %q: expected a JSON string", s), http.StatusBadRequest)
+				return
+			}
+			data = []byte(text)
+		}
+		value, err := handler.values.Decode(data)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid value for %q: %v", s, err), http.StatusBadRequest)
+			return
+		}
+		additions[key] = value
+	}
+	deletions := make([]K, 0, len(patch.Delete))
+	for _, s := range patch.Delete {
+		key, err := handler.keys.ParseKey(s)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid key %q: %v", s, err), http.StatusBadRequest)
+			return
+		}
+		deletions = append(deletions, key)
+	}
+	handler.mutex.Lock()
+	handler.gomap.AddMany(additions).DeleteMany(deletions...)
+	handler.mutex.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,191 @@
+package gomaphttp_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+	"github.com/lindsaygelle/gomap/gomaphttp"
+)
+
+// config is a value served in the handler tests.
+type config struct {
+	Port int `json:"port"`
+}
+
+// do sends a request to the handler and returns the response.
+func do(t *testing.T, handler http.Handler, method string, target string, body string, headers ...string) *httptest.ResponseRecorder {
+	t.Helper()
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	for i := 0; i+1 < len(headers); i += 2 {
+		request.Header.Set(headers[i], headers[i+1])
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// TestHandlerGetPutDelete tests reading, writing and deleting keys.
+func TestHandlerGetPutDelete(t *testing.T) {
+	newMap := &gomap.Map[string, config]{}
+	handler := gomaphttp.NewHandler(newMap, gomaphttp.StringKeys{}, gomaphttp.JSONValues[config]{})
+
+	if response := do(t, handler, http.MethodGet, "/api", ""); response.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodPut, "/api", `{"port": 8080}`); response.Code != http.StatusCreated {
+		t.Errorf("Expected 201, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodPut, "/api", `{"port": 9090}`); response.Code != http.StatusNoContent {
+		t.Errorf("Expected 204, but got %d", response.Code)
+	}
+	if newMap.Fetch("api").Port != 9090 {
+		t.Errorf("Expected the map to be updated, but got %v", newMap)
+	}
+
+	response := do(t, handler, http.MethodGet, "/api", "")
+	if response.Code != http.StatusOK || response.Body.String() != `{"port":9090}` {
+		t.Errorf("Expected 200 with the value, but got %d %q", response.Code, response.Body.String())
+	}
+	if response.Header().Get("Content-Type") != "application/json" || response.Header().Get("ETag") == "" {
+		t.Errorf("Expected Content-Type and ETag headers, but got %v", response.Header())
+	}
+
+	if response := do(t, handler, http.MethodPut, "/api", `{"port": "x"}`); response.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid value, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodPost, "/api", ""); response.Code != http.StatusMethodNotAllowed || response.Header().Get("Allow") == "" {
+		t.Errorf("Expected 405 with an Allow header, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodDelete, "/api", ""); response.Code != http.StatusNoContent || newMap.Has("api") {
+		t.Errorf("Expected 204 and the key to be deleted, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodDelete, "/api", ""); response.Code != http.StatusNotFound {
+		t.Errorf("Expected 404, but got %d", response.Code)
+	}
+
+	// Escaped slashes are part of the key.
+	do(t, handler, http.MethodPut, "/a%2Fb", `{"port": 1}`)
+	if !newMap.Has("a/b") {
+		t.Errorf("Expected the key 'a/b', but got %v", newMap)
+	}
+}
+
+// TestHandlerETag tests conditional requests.
+func TestHandlerETag(t *testing.T) {
+	newMap := &gomap.Map[string, config]{"api": {8080}}
+	handler := gomaphttp.NewHandler(newMap, gomaphttp.StringKeys{}, gomaphttp.JSONValues[config]{})
+	tag := do(t, handler, http.MethodGet, "/api", "").Header().Get("ETag")
+
+	if response := do(t, handler, http.MethodGet, "/api", "", "If-None-Match", tag); response.Code != http.StatusNotModified {
+		t.Errorf("Expected 304, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodPut, "/api", `{"port": 1}`, "If-Match", `"stale"`); response.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected 412, but got %d", response.Code)
+	}
+	response := do(t, handler, http.MethodPut, "/api", `{"port": 1}`, "If-Match", tag)
+	if response.Code != http.StatusNoContent || response.Header().Get("ETag") == tag {
+		t.Errorf("Expected 204 with a new ETag, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodPut, "/api", `{"port": 2}`, "If-None-Match", "*"); response.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected 412 when creating an existing key, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodPut, "/web", `{"port": 2}`, "If-None-Match", "*"); response.Code != http.StatusCreated {
+		t.Errorf("Expected 201 when creating a new key, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodDelete, "/api", "", "If-Match", tag); response.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected 412 when deleting with a stale ETag, but got %d", response.Code)
+	}
+}
+
+// TestHandlerList tests listing keys with pagination.
+func TestHandlerList(t *testing.T) {
+	newMap := &gomap.Map[int, string]{}
+	for i := 0; i < 25; i++ {
+		newMap.Add(i, fmt.Sprint("value-", i))
+	}
+	handler := gomaphttp.NewHandler(newMap, gomaphttp.IntKeys{}, gomaphttp.TextValues{})
+
+	var keys []string
+	cursor := ""
+	for pages := 0; pages < 10; pages++ {
+		response := do(t, handler, http.MethodGet, "/?limit=10&cursor="+cursor, "")
+		var list gomaphttp.List
+		if err := json.Unmarshal(response.Body.Bytes(), &list); err != nil {
+			t.Fatalf("Expected a JSON list, but got %q", response.Body.String())
+		}
+		keys = append(keys, list.Keys...)
+		if cursor = list.NextCursor; cursor == "" {
+			break
+		}
+	}
+	if len(keys) != 25 || keys[0] != "0" || keys[1] != "1" || keys[2] != "10" {
+		t.Errorf("Expected 25 sorted keys, but got %v", keys)
+	}
+
+	var list gomaphttp.List
+	json.Unmarshal(do(t, handler, http.MethodGet, "/?prefix=2", "").Body.Bytes(), &list)
+	if strings.Join(list.Keys, ",") != "2,20,21,22,23,24" || list.NextCursor != "" {
+		t.Errorf("Expected the keys starting with 2, but got %v", list)
+	}
+	if response := do(t, handler, http.MethodGet, "/?limit=0", ""); response.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid limit, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodGet, "/x", ""); response.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid key, but got %d", response.Code)
+	}
+}
+
+// TestHandlerPatch tests bulk updates.
+func TestHandlerPatch(t *testing.T) {
+	newMap := &gomap.Map[string, string]{"a": "1", "b": "2"}
+	handler := gomaphttp.NewHandler(newMap, gomaphttp.StringKeys{}, gomaphttp.TextValues{})
+
+	response := do(t, handler, http.MethodPatch, "/", `{"set": {"c": "3", "a": "one"}, "delete": ["b"]}`)
+	expected := &gomap.Map[string, string]{"a": "one", "c": "3"}
+	if response.Code != http.StatusNoContent || !newMap.Equal(expected) {
+		t.Errorf("Expected 204 and %v, but got %d and %v", expected, response.Code, newMap)
+	}
+	if response := do(t, handler, http.MethodPatch, "/", `{"set": {"d": 4}}`); response.Code != http.StatusBadRequest || newMap.Has("d") {
+		t.Errorf("Expected 400 for a value that is not a string, but got %d", response.Code)
+	}
+	if response := do(t, handler, http.MethodPatch, "/", `{"unknown": true}`); response.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an unknown field, but got %d", response.Code)
+	}
+}
+
+// TestHandlerConcurrent tests concurrent requests.
+func TestHandlerConcurrent(t *testing.T) {
+	handler := gomaphttp.NewHandler(&gomap.Map[int, string]{}, gomaphttp.IntKeys{}, gomaphttp.TextValues{})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				key := fmt.Sprint(server.URL, "/", i*100+j)
+				request, _ := http.NewRequest(http.MethodPut, key, strings.NewReader("value"))
+				if response, err := http.DefaultClient.Do(request); err == nil {
+					response.Body.Close()
+				}
+				if response, err := http.Get(server.URL + "/?limit=5"); err == nil {
+					response.Body.Close()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	handler.View(func(gomap *gomap.Map[int, string]) {
+		if gomap.Length() != 160 {
+			t.Errorf("Expected 160 keys, but got %d", gomap.Length())
+		}
+	})
+}
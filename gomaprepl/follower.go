@@ -0,0 +1,165 @@
+package gomaprepl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// Status describes how far a follower is behind its leader.
+type Status struct {
+	// AppliedSeq is the sequence number of the last operation applied by the follower.
+	AppliedSeq uint64
+	// LeaderSeq is the latest sequence number the follower has been told about by the leader.
+	LeaderSeq uint64
+	// Lag is the number of operations the follower has yet to apply.
+	Lag uint64
+	// Delay is the time between the leader recording the last applied operation and the follower applying it.
+	Delay time.Duration
+	// LastContact is when the follower last received a message from the leader.
+	LastContact time.Time
+	// LastError is the error that ended the last connection made by Follow, or that stopped it from connecting.
+	// It is nil until Follow has lost a connection.
+	LastError error
+}
+
+// Follower applies the operations replicated by a leader to a local map.
+type Follower[K comparable, V any] struct {
+	applied     uint64
+	delay       time.Duration
+	gomap       *gomap.Map[K, V]
+	lastContact time.Time
+	lastError   error
+	leader      string
+	leaderSeq   uint64
+	mutex       sync.RWMutex
+	// Codec decodes the messages sent by the leader. It defaults to JSON and must match the Codec of the leader.
+	Codec Codec
+}
+
+// NewFollower creates a Follower that applies replicated operations to the map.
+//
+//	// Create a new Follower instance.
+//	follower := gomaprepl.NewFollower(&gomap.Map[string, int]{})
+//	go follower.Follow(ctx, func(ctx context.Context) (io.ReadWriteCloser, error) {
+//		return (&net.Dialer{}).DialContext(ctx, "tcp", "leader:7000")
+//	}, time.Second)
+func NewFollower[K comparable, V any](gomap *gomap.Map[K, V]) *Follower[K, V] {
+	return &Follower[K, V]{gomap: gomap, Codec: JSON}
+}
+
+// Follow connects to the leader using dial and replicates from it, reconnecting after the given delay whenever
+// the connection fails. Each reconnection resumes from the last applied operation. The error that ended each
+// connection, or that stopped dial from connecting, is reported by Status. It returns when the context is done.
+func (follower *Follower[K, V]) Follow(ctx context.Context, dial func(ctx context.Context) (io.ReadWriteCloser, error), delay time.Duration) error {
+	for {
+		conn, err := dial(ctx)
+		if err == nil {
+			err = follower.Run(ctx, conn)
+			conn.Close()
+		}
+		if ctx.Err() == nil {
+			follower.mutex.Lock()
+			follower.lastError = err
+			follower.mutex.Unlock()
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Run replicates from the leader connected through the io.ReadWriter until the context is done or the connection
+// fails, and returns the error that stopped it. If the io.ReadWriter is also an io.Closer, it is closed when the
+// context is done. Run can be called again with a new connection to resume replication.
+func (follower *Follower[K, V]) Run(ctx context.Context, rw io.ReadWriter) error {
+	stop := closeOnDone(ctx, rw)
+	defer stop()
+	codec := codecOrDefault(follower.Codec)
+	follower.mutex.RLock()
+	hello := message[K, V]{Leader: follower.leader, Seq: follower.applied, Type: messageHello}
+	follower.mutex.RUnlock()
+	if err := codec.NewEncoder(rw).Encode(hello); err != nil {
+		return contextError(ctx, err)
+	}
+	decoder := codec.NewDecoder(rw)
+	for {
+		var message message[K, V]
+		if err := decoder.Decode(&message); err != nil {
+			return contextError(ctx, err)
+		}
+		if err := follower.apply(message); err != nil {
+			return err
+		}
+	}
+}
+
+// apply applies a message received from the leader.
+func (follower *Follower[K, V]) apply(message message[K, V]) error {
+	follower.mutex.Lock()
+	defer follower.mutex.Unlock()
+	now := time.Now()
+	follower.lastContact = now
+	switch message.Type {
+	case messageSnapshot:
+		follower.gomap.DeleteMany(*follower.gomap.Keys()...)
+		for _, entry := range message.Entries {
+			follower.gomap.Add(entry.Key, entry.Value)
+		}
+		follower.leader = message.Leader
+		follower.applied = message.Seq
+	case messageOp:
+		if message.Seq != follower.applied+1 {
+			return fmt.Errorf("%w: operation %d follows %d", ErrUnexpectedMessage, message.Seq, follower.applied)
+		}
+		switch message.Op {
+		case opAdd:
+			for _, entry := range message.Entries {
+				follower.gomap.Add(entry.Key, entry.Value)
+			}
+		case opDelete:
+			follower.gomap.DeleteMany(message.Keys...)
+		default:
+			return fmt.Errorf("%w: unknown operation %q", ErrUnexpectedMessage, message.Op)
+		}
+		follower.applied = message.Seq
+	case messageHeartbeat:
+	default:
+		return fmt.Errorf("%w: %q", ErrUnexpectedMessage, message.Type)
+	}
+	if message.Type != messageHeartbeat {
+		follower.delay = max(now.Sub(time.Unix(0, message.Time)), 0)
+	}
+	follower.leaderSeq = max(follower.leaderSeq, message.Seq)
+	if message.Type == messageSnapshot {
+		follower.leaderSeq = message.Seq
+	}
+	return nil
+}
+
+// Status returns the replication status of the follower.
+func (follower *Follower[K, V]) Status() Status {
+	follower.mutex.RLock()
+	defer follower.mutex.RUnlock()
+	return Status{
+		AppliedSeq:  follower.applied,
+		LeaderSeq:   follower.leaderSeq,
+		Lag:         follower.leaderSeq - follower.applied,
+		Delay:       follower.delay,
+		LastContact: follower.lastContact,
+		LastError:   follower.lastError,
+	}
+}
+
+// View calls fn with the map while holding the follower's read lock. fn must not modify the map.
+func (follower *Follower[K, V]) View(fn func(gomap *gomap.Map[K, V])) {
+	follower.mutex.RLock()
+	defer follower.mutex.RUnlock()
+	fn(follower.gomap)
+}
@@ -0,0 +1,208 @@
+// Package gomaprepl replicates a gomap.Map from a leader to any number of followers.
+//
+// The leader sends each follower a snapshot of the map followed by an ordered log of the mutations made through it.
+// Followers apply them to a local Map and, after a disconnect, resume from the sequence number of the last
+// mutation they applied. Replication runs over any io.ReadWriter, such as a net.Conn.
+package gomaprepl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// DefaultLogSize is the number of operations a leader retains for followers that reconnect.
+const DefaultLogSize = 1024
+
+// DefaultHeartbeatInterval is how often a leader tells idle followers its latest sequence number.
+const DefaultHeartbeatInterval = time.Second
+
+// ErrUnexpectedMessage is returned when a peer sends a message that is not valid at that point of the protocol.
+var ErrUnexpectedMessage = errors.New("gomaprepl: unexpected message")
+
+// Leader owns the replicated map. All mutations must be made through the Leader so that they are recorded in its log.
+type Leader[K comparable, V any] struct {
+	gomap  *gomap.Map[K, V]
+	id     string
+	log    []message[K, V]
+	mutex  sync.RWMutex
+	notify chan struct{}
+	seq    uint64
+	// Codec encodes the messages sent to followers. It defaults to JSON and must match the Codec of the followers.
+	Codec Codec
+	// HeartbeatInterval is how often idle followers are sent the latest sequence number. It defaults to DefaultHeartbeatInterval.
+	HeartbeatInterval time.Duration
+	// LogSize is the number of operations retained for followers that reconnect. It defaults to DefaultLogSize.
+	// Followers that fall further behind are sent a new snapshot.
+	LogSize int
+}
+
+// NewLeader creates a Leader that replicates the map.
+//
+//	// Create a new Leader instance.
+//	leader := gomaprepl.NewLeader(&gomap.Map[string, int]{})
+//	go func() {
+//		for {
+//			conn, err := listener.Accept()
+//			if err != nil {
+//				return
+//			}
+//			go leader.Serve(ctx, conn)
+//		}
+//	}()
+//	leader.Add("apple", 5)
+func NewLeader[K comparable, V any](gomap *gomap.Map[K, V]) *Leader[K, V] {
+	id := make([]byte, 8)
+	rand.Read(id)
+	return &Leader[K, V]{
+		gomap:             gomap,
+		id:                hex.EncodeToString(id),
+		notify:            make(chan struct{}),
+		Codec:             JSON,
+		HeartbeatInterval: DefaultHeartbeatInterval,
+		LogSize:           DefaultLogSize,
+	}
+}
+
+// record appends the operation to the log and wakes the followers. The caller must hold the write lock.
+func (leader *Leader[K, V]) record(op message[K, V]) {
+	leader.seq++
+	op.Seq, op.Time, op.Type = leader.seq, time.Now().UnixNano(), messageOp
+	leader.log = append(leader.log, op)
+	if size := max(leader.LogSize, 1); len(leader.log) > size {
+		leader.log = append(leader.log[:0:0], leader.log[len(leader.log)-size:]...)
+	}
+	close(leader.notify)
+	leader.notify = make(chan struct{})
+}
+
+// Add inserts or updates the key-value pair and replicates it.
+func (leader *Leader[K, V]) Add(key K, value V) {
+	leader.AddMany(map[K]V{key: value})
+}
+
+// AddMany inserts or updates the key-value pairs of the provided maps and replicates them as one operation.
+func (leader *Leader[K, V]) AddMany(values ...map[K]V) {
+	leader.mutex.Lock()
+	defer leader.mutex.Unlock()
+	var entries []gomap.Entry[K, V]
+	for _, item := range values {
+		for key, value := range item {
+			entries = append(entries, gomap.Entry[K, V]{Key: key, Value: value})
+		}
+	}
+	for _, entry := range entries {
+		leader.gomap.Add(entry.Key, entry.Value)
+	}
+	leader.record(message[K, V]{Entries: entries, Op: opAdd})
+}
+
+// Delete removes the keys and replicates the deletion.
+func (leader *Leader[K, V]) Delete(keys ...K) {
+	leader.mutex.Lock()
+	defer leader.mutex.Unlock()
+	leader.gomap.DeleteMany(keys...)
+	leader.record(message[K, V]{Keys: keys, Op: opDelete})
+}
+
+// Merge merges the other map into the replicated map and replicates the resulting key-value pairs.
+func (leader *Leader[K, V]) Merge(other *gomap.Map[K, V]) {
+	leader.AddMany(*other)
+}
+
+// Seq returns the sequence number of the latest operation.
+func (leader *Leader[K, V]) Seq() uint64 {
+	leader.mutex.RLock()
+	defer leader.mutex.RUnlock()
+	return leader.seq
+}
+
+// View calls fn with the map while holding the leader's read lock. fn must not modify the map.
+func (leader *Leader[K, V]) View(fn func(gomap *gomap.Map[K, V])) {
+	leader.mutex.RLock()
+	defer leader.mutex.RUnlock()
+	fn(leader.gomap)
+}
+
+// pending returns the messages a follower that has applied the operations up to seq needs to catch up,
+// and a channel that is closed when the next operation is recorded.
+func (leader *Leader[K, V]) pending(id string, seq uint64) ([]message[K, V], uint64, <-chan struct{}) {
+	leader.mutex.RLock()
+	defer leader.mutex.RUnlock()
+	first := leader.seq - uint64(len(leader.log)) + 1
+	if id == leader.id && seq+1 >= first && seq <= leader.seq {
+		return leader.log[seq+1-first:], leader.seq, leader.notify
+	}
+	snapshot := message[K, V]{Leader: leader.id, Seq: leader.seq, Time: time.Now().UnixNano(), Type: messageSnapshot}
+	snapshot.Entries = make([]gomap.Entry[K, V], 0, leader.gomap.Length())
+	leader.gomap.Each(func(key K, value V) {
+		snapshot.Entries = append(snapshot.Entries, gomap.Entry[K, V]{Key: key, Value: value})
+	})
+	return []message[K, V]{snapshot}, leader.seq, leader.notify
+}
+
+// Serve replicates the map to the follower connected through the io.ReadWriter until the context is done
+// or the connection fails. If the io.ReadWriter is also an io.Closer, it is closed when the context is done.
+func (leader *Leader[K, V]) Serve(ctx context.Context, rw io.ReadWriter) error {
+	stop := closeOnDone(ctx, rw)
+	defer stop()
+	codec := codecOrDefault(leader.Codec)
+	var hello message[K, V]
+	if err := codec.NewDecoder(rw).Decode(&hello); err != nil {
+		return contextError(ctx, err)
+	}
+	if hello.Type != messageHello {
+		return ErrUnexpectedMessage
+	}
+	encoder := codec.NewEncoder(rw)
+	heartbeat := time.NewTicker(max(leader.HeartbeatInterval, time.Millisecond))
+	defer heartbeat.Stop()
+	id, seq := hello.Leader, hello.Seq
+	for {
+		messages, latest, notify := leader.pending(id, seq)
+		for _, message := range messages {
+			if err := encoder.Encode(message); err != nil {
+				return contextError(ctx, err)
+			}
+		}
+		id, seq = leader.id, latest
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-notify:
+		case <-heartbeat.C:
+			if err := encoder.Encode(message[K, V]{Seq: seq, Time: time.Now().UnixNano(), Type: messageHeartbeat}); err != nil {
+				return contextError(ctx, err)
+			}
+		}
+	}
+}
+
+// closeOnDone closes the io.ReadWriter when the context is done, if it is an io.Closer,
+// so that blocked reads and writes return. The returned function stops watching the context.
+func closeOnDone(ctx context.Context, rw io.ReadWriter) func() {
+	closer, ok := rw.(io.Closer)
+	if !ok {
+		return func() {}
+	}
+	stop := context.AfterFunc(ctx, func() {
+		closer.Close()
+	})
+	return func() {
+		stop()
+	}
+}
+
+// contextError returns the context's error if it is done, since closing the connection causes the error.
+func contextError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
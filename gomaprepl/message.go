@@ -0,0 +1,101 @@
+package gomaprepl
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// Codec encodes and decodes the messages sent over a replication connection. The leader and its followers
+// must use the same Codec.
+type Codec interface {
+	// NewDecoder returns a Decoder that reads messages from the io.Reader.
+	NewDecoder(reader io.Reader) Decoder
+	// NewEncoder returns an Encoder that writes messages to the io.Writer.
+	NewEncoder(writer io.Writer) Encoder
+}
+
+// Decoder reads successive messages from a connection.
+type Decoder interface {
+	Decode(value any) error
+}
+
+// Encoder writes successive messages to a connection.
+type Encoder interface {
+	Encode(value any) error
+}
+
+// JSON is the default Codec. It encodes messages as JSON, so keys and values are decoded by their static type:
+// values held in interfaces, such as the ints of a Map[string, any], are decoded as float64, and maps as map[string]any.
+// Use Gob, or a Codec of your own, to keep the dynamic types of such values.
+var JSON Codec = jsonCodec{}
+
+// Gob is a Codec that encodes messages using encoding/gob, which keeps the dynamic type of values held in interfaces.
+// Types other than the predeclared types and slices of them must be registered with gob.Register on the leader and
+// on each follower.
+var Gob Codec = gobCodec{}
+
+// jsonCodec is the Codec returned by JSON.
+type jsonCodec struct{}
+
+// NewDecoder returns a json.Decoder.
+func (jsonCodec) NewDecoder(reader io.Reader) Decoder {
+	return json.NewDecoder(reader)
+}
+
+// NewEncoder returns a json.Encoder.
+func (jsonCodec) NewEncoder(writer io.Writer) Encoder {
+	return json.NewEncoder(writer)
+}
+
+// gobCodec is the Codec returned by Gob.
+type gobCodec struct{}
+
+// NewDecoder returns a gob.Decoder.
+func (gobCodec) NewDecoder(reader io.Reader) Decoder {
+	return gob.NewDecoder(reader)
+}
+
+// NewEncoder returns a gob.Encoder.
+func (gobCodec) NewEncoder(writer io.Writer) Encoder {
+	return gob.NewEncoder(writer)
+}
+
+// codecOrDefault returns the codec, or JSON if it is nil.
+func codecOrDefault(codec Codec) Codec {
+	if codec == nil {
+		return JSON
+	}
+	return codec
+}
+
+// Message types exchanged between a leader and a follower.
+const (
+	messageHello     = "hello"
+	messageSnapshot  = "snapshot"
+	messageOp        = "op"
+	messageHeartbeat = "heartbeat"
+)
+
+// Operations recorded in the log.
+const (
+	opAdd    = "add"
+	opDelete = "delete"
+)
+
+// message is a message of the replication protocol, encoded by the Codec of the leader and follower.
+//
+// A follower opens a connection by sending a hello message with the leader ID and sequence number of the last
+// operation it applied. The leader replies with the operations that followed it, or with a snapshot if it no longer
+// holds them, and then streams each new operation, sending heartbeats with its latest sequence number while idle.
+type message[K comparable, V any] struct {
+	Entries []gomap.Entry[K, V] `json:"entries,omitempty"`
+	Keys    []K                 `json:"keys,omitempty"`
+	Leader  string              `json:"leader,omitempty"`
+	Op      string              `json:"op,omitempty"`
+	Seq     uint64              `json:"seq"`
+	Time    int64               `json:"time,omitempty"`
+	Type    string              `json:"type"`
+}
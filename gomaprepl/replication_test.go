@@ -0,0 +1,238 @@
+package gomaprepl_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lindsaygelle/gomap"
+	"github.com/lindsaygelle/gomap/gomaprepl"
+)
+
+// connect replicates from the leader to the follower over a net.Pipe until the returned function is called.
+func connect(t *testing.T, leader *gomaprepl.Leader[string, int], follower *gomaprepl.Follower[string, int]) func() {
+	leaderConn, followerConn := net.Pipe()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{}, 2)
+	go func() {
+		leader.Serve(ctx, leaderConn)
+		done <- struct{}{}
+	}()
+	go func() {
+		follower.Run(ctx, followerConn)
+		done <- struct{}{}
+	}()
+	return func() {
+		cancel()
+		<-done
+		<-done
+	}
+}
+
+// waitFor waits until the follower has applied the operations up to the leader's latest sequence number.
+func waitFor(t *testing.T, leader *gomaprepl.Leader[string, int], follower *gomaprepl.Follower[string, int]) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for follower.Status().AppliedSeq != leader.Seq() || follower.Status().LastContact.IsZero() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the follower to catch up to %d, but it applied %d", leader.Seq(), follower.Status().AppliedSeq)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// assertReplicated checks that the follower's map equals the leader's map.
+func assertReplicated(t *testing.T, leader *gomaprepl.Leader[string, int], follower *gomaprepl.Follower[string, int]) {
+	t.Helper()
+	leader.View(func(leaderMap *gomap.Map[string, int]) {
+		follower.View(func(followerMap *gomap.Map[string, int]) {
+			if !followerMap.Equal(leaderMap) {
+				t.Errorf("Expected %v, but got %v", leaderMap, followerMap)
+			}
+		})
+	})
+}
+
+// TestReplication tests that a follower receives the snapshot and subsequent operations.
+func TestReplication(t *testing.T) {
+	leader := gomaprepl.NewLeader(&gomap.Map[string, int]{"apple": 5})
+	leader.Add("banana", 3)
+	follower := gomaprepl.NewFollower(&gomap.Map[string, int]{"stale": 1})
+	disconnect := connect(t, leader, follower)
+	defer disconnect()
+
+	waitFor(t, leader, follower)
+	assertReplicated(t, leader, follower)
+
+	leader.Add("cherry", 8)
+	leader.Delete("apple")
+	leader.Merge(&gomap.Map[string, int]{"banana": 4, "date": 1})
+	waitFor(t, leader, follower)
+	assertReplicated(t, leader, follower)
+	if status := follower.Status(); status.AppliedSeq != 4 || status.Lag != 0 {
+		t.Errorf("Expected the follower to have applied 4 operations without lag, but got %+v", status)
+	}
+}
+
+// TestReplicationResume tests that a follower resumes from its last sequence number after disconnecting.
+func TestReplicationResume(t *testing.T) {
+	leader := gomaprepl.NewLeader(&gomap.Map[string, int]{})
+	leader.LogSize = 4
+	leader.Add("apple", 5)
+	followerMap := &gomap.Map[string, int]{}
+	follower := gomaprepl.NewFollower(followerMap)
+	disconnect := connect(t, leader, follower)
+	waitFor(t, leader, follower)
+	disconnect()
+
+	// A key added only to the follower survives a resume, which sends operations rather than a snapshot.
+	followerMap.Add("local", 1)
+	leader.Add("banana", 3)
+	leader.Add("cherry", 8)
+	disconnect = connect(t, leader, follower)
+	waitFor(t, leader, follower)
+	disconnect()
+	if !followerMap.Has("local") || followerMap.Fetch("cherry") != 8 {
+		t.Errorf("Expected the follower to resume from the log, but got %v", followerMap)
+	}
+
+	// A follower that falls behind the retained log is sent a new snapshot.
+	for i := 0; i < 5; i++ {
+		leader.Add("counter", i)
+	}
+	disconnect = connect(t, leader, follower)
+	defer disconnect()
+	waitFor(t, leader, follower)
+	if followerMap.Has("local") {
+		t.Errorf("Expected the follower to be sent a snapshot, but got %v", followerMap)
+	}
+	assertReplicated(t, leader, follower)
+}
+
+// TestReplicationLag tests the lag reported by a follower.
+func TestReplicationLag(t *testing.T) {
+	leaderConn, followerConn := net.Pipe()
+	follower := gomaprepl.NewFollower(&gomap.Map[string, int]{})
+	done := make(chan error)
+	go func() {
+		done <- follower.Run(context.Background(), followerConn)
+	}()
+
+	// Act as a leader that reports more operations than it sends.
+	decoder, encoder := json.NewDecoder(leaderConn), json.NewEncoder(leaderConn)
+	var hello map[string]any
+	decoder.Decode(&hello)
+	if hello["type"] != "hello" {
+		t.Fatalf("Expected a hello message, but got %v", hello)
+	}
+	encoder.Encode(map[string]any{"type": "snapshot", "seq": 2, "leader": "a", "time": time.Now().Add(-time.Second).UnixNano(),
+		"entries": []map[string]any{{"Key": "apple", "Value": 5}}})
+	encoder.Encode(map[string]any{"type": "heartbeat", "seq": 5})
+	encoder.Encode(map[string]any{"type": "op", "op": "add", "seq": 4})
+	err := <-done
+	if !errors.Is(err, gomaprepl.ErrUnexpectedMessage) {
+		t.Errorf("Expected ErrUnexpectedMessage for an operation out of order, but got %v", err)
+	}
+	status := follower.Status()
+	if status.AppliedSeq != 2 || status.LeaderSeq != 5 || status.Lag != 3 {
+		t.Errorf("Expected a lag of 3 operations, but got %+v", status)
+	}
+	if status.Delay < time.Second {
+		t.Errorf("Expected a delay of at least a second, but got %v", status.Delay)
+	}
+	leaderConn.Close()
+}
+
+// TestCodec tests that the Gob codec keeps the dynamic types of values held in interfaces.
+func TestCodec(t *testing.T) {
+	for _, test := range []struct {
+		codec    gomaprepl.Codec
+		expected any
+	}{
+		{gomaprepl.JSON, float64(5)},
+		{gomaprepl.Gob, 5},
+	} {
+		leader := gomaprepl.NewLeader(&gomap.Map[string, any]{"apple": 5})
+		follower := gomaprepl.NewFollower(&gomap.Map[string, any]{})
+		leader.Codec, follower.Codec = test.codec, test.codec
+		leaderConn, followerConn := net.Pipe()
+		ctx, cancel := context.WithCancel(context.Background())
+		go leader.Serve(ctx, leaderConn)
+		go follower.Run(ctx, followerConn)
+		deadline := time.Now().Add(5 * time.Second)
+		for follower.Status().LastContact.IsZero() && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		follower.View(func(followerMap *gomap.Map[string, any]) {
+			if value := followerMap.Fetch("apple"); value != test.expected {
+				t.Errorf("Expected %#v, but got %#v", test.expected, value)
+			}
+		})
+		cancel()
+	}
+}
+
+// TestFollowError tests that Follow reports the error that ended the last connection through Status.
+func TestFollowError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	follower := gomaprepl.NewFollower(&gomap.Map[string, int]{})
+	errDial := errors.New("connection refused")
+	go follower.Follow(ctx, func(ctx context.Context) (io.ReadWriteCloser, error) {
+		return nil, errDial
+	}, time.Millisecond)
+	deadline := time.Now().Add(5 * time.Second)
+	for follower.Status().LastError == nil && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := follower.Status().LastError; !errors.Is(err, errDial) {
+		t.Errorf("Expected the dial error, but got %v", err)
+	}
+}
+
+// TestFollow tests that Follow reconnects over TCP after the connection is lost.
+func TestFollow(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected a loopback listener, but got %v", err)
+	}
+	defer listener.Close()
+	leader := gomaprepl.NewLeader(&gomap.Map[string, int]{"apple": 5})
+	leader.HeartbeatInterval = 10 * time.Millisecond
+	conns := make(chan net.Conn, 10)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns <- conn
+			go leader.Serve(context.Background(), conn)
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	follower := gomaprepl.NewFollower(&gomap.Map[string, int]{})
+	done := make(chan error)
+	go func() {
+		done <- follower.Follow(ctx, func(ctx context.Context) (io.ReadWriteCloser, error) {
+			return (&net.Dialer{}).DialContext(ctx, "tcp", listener.Addr().String())
+		}, 10*time.Millisecond)
+	}()
+	waitFor(t, leader, follower)
+
+	// Drop the connection and make changes while the follower reconnects.
+	(<-conns).Close()
+	leader.Add("banana", 3)
+	waitFor(t, leader, follower)
+	assertReplicated(t, leader, follower)
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+}
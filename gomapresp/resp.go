@@ -0,0 +1,132 @@
+package gomapresp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Default limits on the requests accepted from a client, used when the limits of a Server are not set.
+const (
+	// DefaultMaxBulkLength is the default length in bytes of the largest bulk string accepted from a client.
+	DefaultMaxBulkLength = 1 << 20
+	// DefaultMaxLineLength is the default length in bytes of the longest inline command or header line
+	// accepted from a client.
+	DefaultMaxLineLength = 64 << 10
+)
+
+// maxArrayLength is the largest number of arguments accepted in one command.
+const maxArrayLength = 1 << 20
+
+// limits holds the limits on the requests read from a client.
+type limits struct {
+	maxBulkLength int
+	maxLineLength int
+}
+
+// errProtocol is returned when a client sends a request that is not valid RESP.
+var errProtocol = errors.New("protocol error")
+
+// readCommand reads a command sent as a RESP array of bulk strings, or as an inline command of space-separated words.
+// Lines and bulk strings longer than the limits are rejected before they are buffered.
+func readCommand(reader *bufio.Reader, limits limits) ([]string, error) {
+	line, err := readLine(reader, limits.maxLineLength)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(line, "*") {
+		return strings.Fields(line), nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n > maxArrayLength {
+		return nil, fmt.Errorf("%w: invalid multibulk length", errProtocol)
+	}
+	args := make([]string, 0, min(max(n, 0), 64))
+	for i := 0; i < n; i++ {
+		line, err := readLine(reader, limits.maxLineLength)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(line, "$") {
+			return nil, fmt.Errorf("%w: expected '$', got '%s'", errProtocol, line[:min(len(line), 1)])
+		}
+		length, err := strconv.Atoi(line[1:])
+		if err != nil || length < 0 || length > limits.maxBulkLength {
+			return nil, fmt.Errorf("%w: invalid bulk length", errProtocol)
+		}
+		data := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		if string(data[length:]) != "\r\n" {
+			return nil, fmt.Errorf("%w: bulk string is not terminated by CRLF", errProtocol)
+		}
+		args = append(args, string(data[:length]))
+	}
+	return args, nil
+}
+
+// readLine reads a line terminated by CRLF or LF and returns it without the terminator.
+// It returns a protocol error as soon as the line is longer than maxLength bytes.
+func readLine(reader *bufio.Reader, maxLength int) (string, error) {
+	var line []byte
+	for {
+		fragment, err := reader.ReadSlice('\n')
+		if len(line)+len(fragment) > maxLength+2 {
+			return "", fmt.Errorf("%w: too big inline request", errProtocol)
+		}
+		line = append(line, fragment...)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return "", err
+		}
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(string(line), "\n"), "\r"), nil
+}
+
+// writer writes RESP replies.
+type writer struct {
+	*bufio.Writer
+}
+
+// simple writes a simple string reply.
+func (w writer) simple(s string) {
+	w.WriteString("+" + s + "\r\n")
+}
+
+// error writes an error reply.
+func (w writer) error(s string) {
+	w.WriteString("-" + s + "\r\n")
+}
+
+// integer writes an integer reply.
+func (w writer) integer(n int) {
+	w.WriteString(":" + strconv.Itoa(n) + "\r\n")
+}
+
+// bulk writes a bulk string reply, or a null bulk string if ok is false.
+func (w writer) bulk(s string, ok bool) {
+	if !ok {
+		w.WriteString("$-1\r\n")
+		return
+	}
+	w.WriteString("$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n")
+}
+
+// array writes the header of an array reply with n elements.
+func (w writer) array(n int) {
+	w.WriteString("*" + strconv.Itoa(n) + "\r\n")
+}
+
+// strings writes an array reply of bulk strings.
+func (w writer) strings(values []string) {
+	w.array(len(values))
+	for _, value := range values {
+		w.bulk(value, true)
+	}
+}
@@ -0,0 +1,456 @@
+// Package gomapresp serves a gomap.Map over a subset of the Redis protocol (RESP),
+// so that in-process maps can be inspected and modified with redis-cli during local debugging.
+//
+// The supported commands are GET, SET (with the EX and PX options), DEL, EXISTS, KEYS, SCAN, MGET, MSET,
+// DBSIZE, FLUSHDB, EXPIRE, TTL, PING, COMMAND and QUIT. Keys with an expiry are removed lazily
+// the next time a command is run after they expire. Requests with lines or values longer than the
+// MaxLineLength and MaxBulkLength of the Server are answered with an error and the connection is closed.
+package gomapresp
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// ErrServerClosed is returned by Serve after Close is called.
+var ErrServerClosed = errors.New("gomapresp: server closed")
+
+// Server serves a Map of strings to Redis clients. It is safe for concurrent connections; code that accesses
+// the Map while the server is running must use Update and View.
+type Server struct {
+	closed    bool
+	conns     map[net.Conn]struct{}
+	expires   map[string]time.Time
+	gomap     *gomap.Map[string, string]
+	listeners map[net.Listener]struct{}
+	mutex     sync.Mutex
+	// MaxBulkLength is the length in bytes of the largest bulk string accepted from a client.
+	// It defaults to DefaultMaxBulkLength. Set it before serving connections.
+	MaxBulkLength int
+	// MaxLineLength is the length in bytes of the longest inline command or header line accepted from a client.
+	// It defaults to DefaultMaxLineLength. Set it before serving connections.
+	MaxLineLength int
+	// Now returns the current time and is used to expire keys. It defaults to time.Now.
+	Now func() time.Time
+}
+
+// NewServer creates a Server for the map.
+//
+//	// Serve a new Map instance on the default Redis port.
+//	newMap := &gomap.Map[string, string]{"greeting": "hello"}
+//	server := gomapresp.NewServer(newMap)
+//	go server.ListenAndServe("localhost:6379")
+//	// $ redis-cli GET greeting
+//	// "hello"
+func NewServer(gomap *gomap.Map[string, string]) *Server {
+	return &Server{
+		conns:         make(map[net.Conn]struct{}),
+		expires:       make(map[string]time.Time),
+		gomap:         gomap,
+		listeners:     make(map[net.Listener]struct{}),
+		MaxBulkLength: DefaultMaxBulkLength,
+		MaxLineLength: DefaultMaxLineLength,
+		Now:           time.Now,
+	}
+}
+
+// ListenAndServe listens on the TCP address and serves connections until the server is closed.
+func (server *Server) ListenAndServe(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	return server.Serve(listener)
+}
+
+// Serve accepts connections on the listener and serves each on its own goroutine until the server is closed.
+// It always returns a non-nil error, which is ErrServerClosed after Close.
+func (server *Server) Serve(listener net.Listener) error {
+	server.mutex.Lock()
+	if server.closed {
+		server.mutex.Unlock()
+		listener.Close()
+		return ErrServerClosed
+	}
+	server.listeners[listener] = struct{}{}
+	server.mutex.Unlock()
+	defer func() {
+		server.mutex.Lock()
+		delete(server.listeners, listener)
+		server.mutex.Unlock()
+	}()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			server.mutex.Lock()
+			closed := server.closed
+			server.mutex.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return err
+		}
+		go server.ServeConn(conn)
+	}
+}
+
+// ServeConn serves commands sent on the connection until the client disconnects or sends QUIT,
+// and then closes the connection.
+func (server *Server) ServeConn(conn net.Conn) {
+	server.mutex.Lock()
+	if server.closed {
+		server.mutex.Unlock()
+		conn.Close()
+		return
+	}
+	server.conns[conn] = struct{}{}
+	server.mutex.Unlock()
+	defer func() {
+		server.mutex.Lock()
+		delete(server.conns, conn)
+		server.mutex.Unlock()
+		conn.Close()
+	}()
+	limits := limits{maxBulkLength: server.MaxBulkLength, maxLineLength: server.MaxLineLength}
+	if limits.maxBulkLength <= 0 {
+		limits.maxBulkLength = DefaultMaxBulkLength
+	}
+	if limits.maxLineLength <= 0 {
+		limits.maxLineLength = DefaultMaxLineLength
+	}
+	reader, w := bufio.NewReader(conn), writer{bufio.NewWriter(conn)}
+	for {
+		args, err := readCommand(reader, limits)
+		if err != nil {
+			if errors.Is(err, errProtocol) {
+				w.error("ERR " + err.Error())
+				w.Flush()
+			}
+			return
+		}
+		if len(args) == 0 {
+			continue
+		}
+		quit := strings.EqualFold(args[0], "QUIT")
+		if quit {
+			w.simple("OK")
+		} else {
+			server.execute(w, args)
+		}
+		if err := w.Flush(); err != nil || quit {
+			return
+		}
+	}
+}
+
+// Close stops all listeners and closes all connections.
+func (server *Server) Close() error {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.closed = true
+	var errs []error
+	for listener := range server.listeners {
+		errs = append(errs, listener.Close())
+	}
+	for conn := range server.conns {
+		errs = append(errs, conn.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Update calls fn with the map while holding the server's lock.
+func (server *Server) Update(fn func(gomap *gomap.Map[string, string])) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	fn(server.gomap)
+}
+
+// View calls fn with the map while holding the server's lock. fn must not modify the map.
+func (server *Server) View(fn func(gomap *gomap.Map[string, string])) {
+	server.Update(fn)
+}
+
+// arity is the number of arguments, including the command name, accepted by each command.
+// A negative arity is the minimum number of arguments.
+var arity = map[string]int{
+	"COMMAND": -1,
+	"DBSIZE":  1,
+	"DEL":     -2,
+	"EXISTS":  -2,
+	"EXPIRE":  3,
+	"FLUSHDB": -1,
+	"GET":     2,
+	"KEYS":    2,
+	"MGET":    -2,
+	"MSET":    -3,
+	"PING":    -1,
+	"SCAN":    -2,
+	"SET":     -3,
+	"TTL":     2,
+}
+
+// execute runs the command and writes its reply.
+func (server *Server) execute(w writer, args []string) {
+	name := strings.ToUpper(args[0])
+	n, ok := arity[name]
+	if !ok {
+		w.error("ERR unknown command '" + args[0] + "'")
+		return
+	}
+	if n > 0 && len(args) != n || n < 0 && len(args) < -n {
+		w.error("ERR wrong number of arguments for '" + strings.ToLower(name) + "' command")
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.expire()
+	switch name {
+	case "COMMAND":
+		w.array(0)
+	case "DBSIZE":
+		w.integer(server.gomap.Length())
+	case "DEL":
+		// DeleteManyOK reports whether each key is absent afterwards, so count the keys present beforehand instead.
+		keys := args[1:]
+		sort.Strings(keys)
+		keys = slices.Compact(keys)
+		deleted := 0
+		for _, ok := range *server.gomap.HasMany(keys...) {
+			if ok {
+				deleted++
+			}
+		}
+		server.gomap.DeleteMany(keys...)
+		for _, key := range keys {
+			delete(server.expires, key)
+		}
+		w.integer(deleted)
+	case "EXISTS":
+		count := 0
+		for _, ok := range *server.gomap.HasMany(args[1:]...) {
+			if ok {
+				count++
+			}
+		}
+		w.integer(count)
+	case "EXPIRE":
+		seconds, err := strconv.Atoi(args[2])
+		if err != nil {
+			w.error("ERR value is not an integer or out of range")
+			return
+		}
+		if !server.gomap.Has(args[1]) {
+			w.integer(0)
+			return
+		}
+		server.expires[args[1]] = server.Now().Add(time.Duration(seconds) * time.Second)
+		server.expire()
+		w.integer(1)
+	case "FLUSHDB":
+		server.gomap.DeleteMany(*server.gomap.Keys()...)
+		server.expires = make(map[string]time.Time)
+		w.simple("OK")
+	case "GET":
+		w.bulk(server.gomap.Get(args[1]))
+	case "KEYS":
+		w.strings(server.keys(args[1]))
+	case "MGET":
+		w.array(len(args) - 1)
+		for _, key := range args[1:] {
+			w.bulk(server.gomap.Get(key))
+		}
+	case "MSET":
+		if len(args)%2 != 1 {
+			w.error("ERR wrong number of arguments for 'mset' command")
+			return
+		}
+		for i := 1; i < len(args); i += 2 {
+			server.gomap.Add(args[i], args[i+1])
+			delete(server.expires, args[i])
+		}
+		w.simple("OK")
+	case "PING":
+		if len(args) > 1 {
+			w.bulk(args[1], true)
+			return
+		}
+		w.simple("PONG")
+	case "SCAN":
+		server.scan(w, args[1:])
+	case "SET":
+		server.set(w, args[1:])
+	case "TTL":
+		switch expires, ok := server.expires[args[1]]; {
+		case !server.gomap.Has(args[1]):
+			w.integer(-2)
+		case !ok:
+			w.integer(-1)
+		default:
+			w.integer(int((expires.Sub(server.Now()) + time.Second - 1) / time.Second))
+		}
+	}
+}
+
+// expire removes the keys whose expiry has passed. The caller must hold the lock.
+func (server *Server) expire() {
+	now := server.Now()
+	for key, expires := range server.expires {
+		if !now.Before(expires) {
+			server.gomap.Delete(key)
+			delete(server.expires, key)
+		}
+	}
+}
+
+// keys returns the sorted keys that match the glob pattern. The caller must hold the lock.
+func (server *Server) keys(pattern string) []string {
+	keys := []string(*server.gomap.KeysFunc(func(key string) bool {
+		return match(pattern, key)
+	}))
+	sort.Strings(keys)
+	return keys
+}
+
+// scan replies with a page of keys. The cursor is the number of keys, in sorted order, returned by previous calls.
+func (server *Server) scan(w writer, args []string) {
+	cursor, err := strconv.Atoi(args[0])
+	if err != nil || cursor < 0 {
+		w.error("ERR invalid cursor")
+		return
+	}
+	pattern, count := "*", 10
+	for i := 1; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			w.error("ERR syntax error")
+			return
+		}
+		switch strings.ToUpper(args[i]) {
+		case "MATCH":
+			pattern = args[i+1]
+		case "COUNT":
+			if count, err = strconv.Atoi(args[i+1]); err != nil || count < 1 {
+				w.error("ERR value is out of range, must be positive")
+				return
+			}
+		default:
+			w.error("ERR syntax error")
+			return
+		}
+	}
+	keys := server.keys("*")
+	end := min(cursor+count, len(keys))
+	next := end
+	if end >= len(keys) {
+		next = 0
+	}
+	var page []string
+	for _, key := range keys[min(cursor, len(keys)):end] {
+		if match(pattern, key) {
+			page = append(page, key)
+		}
+	}
+	w.array(2)
+	w.bulk(strconv.Itoa(next), true)
+	w.strings(page)
+}
+
+// set stores a value with an optional expiry given by the EX or PX option.
+func (server *Server) set(w writer, args []string) {
+	key, value := args[0], args[1]
+	var ttl time.Duration
+	for i := 2; i < len(args); i++ {
+		option := strings.ToUpper(args[i])
+		if (option != "EX" && option != "PX") || i+1 >= len(args) {
+			w.error("ERR syntax error")
+			return
+		}
+		n, err := strconv.Atoi(args[i+1])
+		if err != nil || n < 1 {
+			w.error("ERR invalid expire time in 'set' command")
+			return
+		}
+		ttl = time.Duration(n) * time.Second
+		if option == "PX" {
+			ttl = time.Duration(n) * time.Millisecond
+		}
+		i++
+	}
+	server.gomap.Add(key, value)
+	delete(server.expires, key)
+	if ttl > 0 {
+		server.expires[key] = server.Now().Add(ttl)
+	}
+	w.simple("OK")
+}
+
+// match reports whether the string matches the Redis glob pattern, which supports *, ?, [...] character classes
+// with ranges and ^ negation, and \ escapes.
+func match(pattern string, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(s); i++ {
+				if match(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(s) == 0 {
+				return false
+			}
+		case '[':
+			if len(s) == 0 {
+				return false
+			}
+			end := strings.IndexByte(pattern[1:], ']') + 1
+			if end == 0 {
+				return false
+			}
+			class := pattern[1:end]
+			negate := strings.HasPrefix(class, "^")
+			if negate {
+				class = class[1:]
+			}
+			matched := false
+			for i := 0; i < len(class); i++ {
+				if i+2 < len(class) && class[i+1] == '-' {
+					matched = matched || s[0] >= class[i] && s[0] <= class[i+2]
+					i += 2
+				} else {
+					matched = matched || s[0] == class[i]
+				}
+			}
+			if matched == negate {
+				return false
+			}
+			pattern = pattern[end:]
+		case '\\':
+			if len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+		default:
+			if len(s) == 0 || s[0] != pattern[0] {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return len(s) == 0
+}
@@ -0,0 +1,271 @@
+package gomapresp_test
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lindsaygelle/gomap"
+	"github.com/lindsaygelle/gomap/gomapresp"
+)
+
+// client is a minimal RESP client.
+type client struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// respError is an error reply.
+type respError string
+
+// Error returns the error message.
+func (err respError) Error() string {
+	return string(err)
+}
+
+// do sends the command as an array of bulk strings and returns the reply. Simple strings and bulk strings
+// are returned as strings, null bulk strings as nil, integers as ints, arrays as []any and errors as respError.
+func (client *client) do(t *testing.T, args ...string) any {
+	t.Helper()
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&builder, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(client.conn, builder.String()); err != nil {
+		t.Fatalf("Expected the command to be sent, but got %v", err)
+	}
+	reply, err := client.read()
+	if err != nil {
+		t.Fatalf("Expected a reply to %v, but got %v", args, err)
+	}
+	return reply
+}
+
+// read reads one reply.
+func (client *client) read() (any, error) {
+	line, err := client.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return respError(line[1:]), nil
+	case ':':
+		return strconv.Atoi(line[1:])
+	case '$':
+		n, _ := strconv.Atoi(line[1:])
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		_, err := io.ReadFull(client.reader, data)
+		return string(data[:n]), err
+	case '*':
+		n, _ := strconv.Atoi(line[1:])
+		elements := make([]any, n)
+		for i := range elements {
+			if elements[i], err = client.read(); err != nil {
+				return nil, err
+			}
+		}
+		return elements, nil
+	}
+	return nil, fmt.Errorf("unexpected reply %q", line)
+}
+
+// newClient starts a server for the map on a loopback listener and connects a client to it.
+// The configure functions are called on the server before it starts serving.
+func newClient(t *testing.T, newMap *gomap.Map[string, string], configure ...func(*gomapresp.Server)) (*gomapresp.Server, *client) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected a loopback listener, but got %v", err)
+	}
+	server := gomapresp.NewServer(newMap)
+	for _, fn := range configure {
+		fn(server)
+	}
+	go server.Serve(listener)
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Expected to connect, but got %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		server.Close()
+	})
+	return server, &client{conn: conn, reader: bufio.NewReader(conn)}
+}
+
+// TestServerCommands tests the basic commands.
+func TestServerCommands(t *testing.T) {
+	newMap := &gomap.Map[string, string]{"greeting": "hello"}
+	server, client := newClient(t, newMap)
+
+	tests := []struct {
+		args     []string
+		expected any
+	}{
+		{[]string{"PING"}, "PONG"},
+		{[]string{"ping", "hi"}, "hi"},
+		{[]string{"GET", "greeting"}, "hello"},
+		{[]string{"GET", "missing"}, nil},
+		{[]string{"SET", "name", "ada lovelace"}, "OK"},
+		{[]string{"MSET", "a", "1", "b", "2"}, "OK"},
+		{[]string{"MGET", "a", "missing", "name"}, []any{"1", nil, "ada lovelace"}},
+		{[]string{"EXISTS", "a", "b", "missing", "a"}, 3},
+		{[]string{"DBSIZE"}, 4},
+		{[]string{"KEYS", "*"}, []any{"a", "b", "greeting", "name"}},
+		{[]string{"KEYS", "[ab]"}, []any{"a", "b"}},
+		{[]string{"KEYS", "g?eet*"}, []any{"greeting"}},
+		{[]string{"DEL", "a", "b", "missing"}, 2},
+		{[]string{"COMMAND", "DOCS"}, []any{}},
+		{[]string{"FLUSHDB"}, "OK"},
+		{[]string{"DBSIZE"}, 0},
+		{[]string{"GET"}, respError("ERR wrong number of arguments for 'get' command")},
+		{[]string{"MSET", "a"}, respError("ERR wrong number of arguments for 'mset' command")},
+		{[]string{"HGET", "a", "b"}, respError("ERR unknown command 'HGET'")},
+	}
+	for _, test := range tests {
+		if reply := client.do(t, test.args...); !reflect.DeepEqual(reply, test.expected) {
+			t.Errorf("%v: expected %#v, but got %#v", test.args, test.expected, reply)
+		}
+	}
+
+	// Changes made by clients are visible in the map.
+	client.do(t, "SET", "color", "blue")
+	server.View(func(newMap *gomap.Map[string, string]) {
+		if newMap.Fetch("color") != "blue" {
+			t.Errorf("Expected the map to hold color=blue, but got %v", newMap)
+		}
+	})
+}
+
+// TestServerScan tests SCAN pagination.
+func TestServerScan(t *testing.T) {
+	newMap := &gomap.Map[string, string]{}
+	for i := 0; i < 25; i++ {
+		newMap.Add(fmt.Sprintf("key:%02d", i), "value")
+	}
+	newMap.Add("other", "value")
+	_, client := newClient(t, newMap)
+
+	var keys []any
+	cursor := "0"
+	for calls := 0; calls < 10; calls++ {
+		reply := client.do(t, "SCAN", cursor, "MATCH", "key:*", "COUNT", "10").([]any)
+		keys = append(keys, reply[1].([]any)...)
+		if cursor = reply[0].(string); cursor == "0" {
+			break
+		}
+	}
+	if len(keys) != 25 || keys[0] != "key:00" || keys[24] != "key:24" {
+		t.Errorf("Expected the 25 matching keys, but got %v", keys)
+	}
+	if reply := client.do(t, "SCAN", "x"); !reflect.DeepEqual(reply, respError("ERR invalid cursor")) {
+		t.Errorf("Expected an invalid cursor error, but got %#v", reply)
+	}
+}
+
+// TestServerExpire tests EXPIRE, TTL and SET with EX and PX.
+func TestServerExpire(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	server, client := newClient(t, &gomap.Map[string, string]{"a": "1", "b": "2"})
+	server.Now = func() time.Time { return now }
+
+	if reply := client.do(t, "EXPIRE", "a", "10"); reply != 1 {
+		t.Errorf("Expected 1, but got %#v", reply)
+	}
+	if reply := client.do(t, "EXPIRE", "missing", "10"); reply != 0 {
+		t.Errorf("Expected 0, but got %#v", reply)
+	}
+	client.do(t, "SET", "c", "3", "PX", "1500")
+	client.do(t, "SET", "d", "4", "EX", "100")
+	for key, expected := range map[string]int{"a": 10, "b": -1, "c": 2, "missing": -2} {
+		if reply := client.do(t, "TTL", key); reply != expected {
+			t.Errorf("TTL %s: expected %d, but got %#v", key, expected, reply)
+		}
+	}
+
+	now = now.Add(10 * time.Second)
+	if reply := client.do(t, "MGET", "a", "b", "c", "d"); !reflect.DeepEqual(reply, []any{nil, "2", nil, "4"}) {
+		t.Errorf("Expected a and c to expire, but got %#v", reply)
+	}
+	client.do(t, "SET", "d", "5")
+	if reply := client.do(t, "TTL", "d"); reply != -1 {
+		t.Errorf("Expected SET to clear the expiry, but got %#v", reply)
+	}
+	if reply := client.do(t, "SET", "e", "1", "EX", "0"); !reflect.DeepEqual(reply, respError("ERR invalid expire time in 'set' command")) {
+		t.Errorf("Expected an invalid expire time error, but got %#v", reply)
+	}
+}
+
+// TestServerInline tests inline commands and QUIT.
+func TestServerInline(t *testing.T) {
+	_, client := newClient(t, &gomap.Map[string, string]{"a": "1"})
+	io.WriteString(client.conn, "GET a\r\nQUIT\r\n")
+	for _, expected := range []any{"1", "OK"} {
+		if reply, err := client.read(); err != nil || reply != expected {
+			t.Errorf("Expected %#v, but got %#v, %v", expected, reply, err)
+		}
+	}
+	if _, err := client.read(); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected the connection to be closed, but got %v", err)
+	}
+}
+
+// TestServerLimits tests that lines and bulk strings longer than the limits of the server are rejected.
+func TestServerLimits(t *testing.T) {
+	tests := []struct {
+		request  string
+		expected string
+	}{
+		{"GET " + strings.Repeat("a", 64) + "\r\n", "ERR protocol error: too big inline request"},
+		{"*2\r\n$3\r\nGET\r\n$65\r\n", "ERR protocol error: invalid bulk length"},
+		{"*1\r\n$" + strings.Repeat("1", 64) + "\r\n", "ERR protocol error: too big inline request"},
+	}
+	for _, test := range tests {
+		_, client := newClient(t, &gomap.Map[string, string]{}, func(server *gomapresp.Server) {
+			server.MaxBulkLength = 64
+			server.MaxLineLength = 32
+		})
+		io.WriteString(client.conn, test.request)
+		if reply, err := client.read(); err != nil || reply != respError(test.expected) {
+			t.Errorf("Expected %q, but got %#v, %v", test.expected, reply, err)
+		}
+	}
+	_, client := newClient(t, &gomap.Map[string, string]{}, func(server *gomapresp.Server) {
+		server.MaxBulkLength = 64
+	})
+	if reply := client.do(t, "SET", "a", strings.Repeat("a", 64)); reply != "OK" {
+		t.Errorf("Expected a bulk string at the limit to be accepted, but got %#v", reply)
+	}
+}
+
+// TestServerClose tests that Serve returns ErrServerClosed.
+func TestServerClose(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Expected a loopback listener, but got %v", err)
+	}
+	server := gomapresp.NewServer(&gomap.Map[string, string]{})
+	done := make(chan error)
+	go func() {
+		done <- server.Serve(listener)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	server.Close()
+	if err := <-done; !errors.Is(err, gomapresp.ErrServerClosed) {
+		t.Errorf("Expected ErrServerClosed, but got %v", err)
+	}
+}
@@ -0,0 +1,297 @@
+package gomaptest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// Option configures how the assertions compare values.
+type Option[V any] func(options *assertOptions[V])
+
+// assertOptions holds the settings of an assertion.
+type assertOptions[V any] struct {
+	equal func(a V, b V) bool
+}
+
+// WithEqualFunc compares values using the function instead of the default comparison, in the same way as
+// Map.EqualFunc. By default, values that implement gomap.Equaler are compared with their Equal method and
+// other values with reflect.DeepEqual.
+func WithEqualFunc[V any](fn func(a V, b V) bool) Option[V] {
+	return func(options *assertOptions[V]) {
+		options.equal = fn
+	}
+}
+
+// newAssertOptions applies the options to the defaults.
+func newAssertOptions[V any](options []Option[V]) *assertOptions[V] {
+	assertOptions := &assertOptions[V]{equal: func(a, b V) bool {
+		if equaler, ok := any(a).(gomap.Equaler[V]); ok {
+			// Equal is not called on nil values, as a pointer receiver may dereference them.
+			if aNil, bNil := isNil(a), isNil(b); aNil || bNil {
+				return aNil == bNil
+			}
+			return equaler.Equal(b)
+		}
+		return reflect.DeepEqual(a, b)
+	}}
+	for _, option := range options {
+		option(assertOptions)
+	}
+	return assertOptions
+}
+
+// AssertContainsKeys reports an error listing the keys that are missing from the map, in sorted order,
+// and returns whether every key is present.
+func AssertContainsKeys[K comparable, V any](t testing.TB, got *gomap.Map[K, V], keys ...K) bool {
+	t.Helper()
+	var lines []string
+	for _, key := range keys {
+		if got.Not(key) {
+			lines = append(lines, "- "+format(key))
+		}
+	}
+	if len(lines) == 0 {
+		return true
+	}
+	sort.Strings(lines)
+	t.Errorf("gomaptest: map is missing %d of %d keys:\n%s", len(lines), len(keys), indent(lines))
+	return false
+}
+
+// AssertEqual reports a line-by-line diff of the keys that are missing from got (-), extra in got (+)
+// and changed (~) if the maps are not equal, and returns whether they are equal. Keys are sorted by their
+// formatted representation, and changed values are followed by the fields, elements or keys that differ.
+//
+//	func TestConfig(t *testing.T) {
+//		want := &gomap.Map[string, int]{"port": 8080, "workers": 4}
+//		gomaptest.AssertEqual(t, want, load())
+//	}
+//
+//	// gomaptest: maps are not equal (-want +got):
+//	//     + "debug": 1
+//	//     ~ "port": 8080 != 9090
+//	//     - "workers": 4
+func AssertEqual[K comparable, V any](t testing.TB, want *gomap.Map[K, V], got *gomap.Map[K, V], options ...Option[V]) bool {
+	t.Helper()
+	lines := diff(want, got, newAssertOptions(options), true)
+	if len(lines) == 0 {
+		return true
+	}
+	t.Errorf("gomaptest: maps are not equal (-want +got):\n%s", indent(lines))
+	return false
+}
+
+// AssertSubset reports a line-by-line diff of the key-value pairs of want that are missing from got (-)
+// or changed in got (~), and returns whether got holds every key-value pair of want.
+func AssertSubset[K comparable, V any](t testing.TB, want *gomap.Map[K, V], got *gomap.Map[K, V], options ...Option[V]) bool {
+	t.Helper()
+	lines := diff(want, got, newAssertOptions(options), false)
+	if len(lines) == 0 {
+		return true
+	}
+	t.Errorf("gomaptest: map is not a superset (-want +got):\n%s", indent(lines))
+	return false
+}
+
+// diff returns the sorted lines describing the keys missing from got, changed in got and,
+// if extra is true, present only in got.
+func diff[K comparable, V any](want *gomap.Map[K, V], got *gomap.Map[K, V], options *assertOptions[V], extra bool) []string {
+	type entry struct {
+		key   string
+		lines []string
+	}
+	var entries []entry
+	want.Each(func(key K, wantValue V) {
+		gotValue, ok := got.Get(key)
+		switch {
+		case !ok:
+			entries = append(entries, entry{format(key), []string{fmt.Sprintf("- %s: %s", format(key), format(wantValue))}})
+		case !options.equal(wantValue, gotValue):
+			entries = append(entries, entry{format(key), changed(format(key), wantValue, gotValue)})
+		}
+	})
+	if extra {
+		got.Each(func(key K, gotValue V) {
+			if want.Not(key) {
+				entries = append(entries, entry{format(key), []string{fmt.Sprintf("+ %s: %s", format(key), format(gotValue))}})
+			}
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	var lines []string
+	for _, entry := range entries {
+		lines = append(lines, entry.lines...)
+	}
+	return lines
+}
+
+// difference is a path within two values at which they differ, and the formatted values at that path.
+type difference struct {
+	got  string
+	path string
+	want string
+}
+
+// missing is the formatted value of an element or key that is not present.
+const missing = "<missing>"
+
+// changed describes a changed value on one line, or on one line per differing field, element or key
+// if the value is a struct, slice, array or map.
+func changed(key string, want any, got any) []string {
+	differences := valueDiff("", reflect.ValueOf(want), reflect.ValueOf(got), map[visit]bool{})
+	if len(differences) == 0 || (len(differences) == 1 && differences[0].path == "") {
+		return []string{fmt.Sprintf("~ %s: %s != %s", key, format(want), format(got))}
+	}
+	lines := []string{fmt.Sprintf("~ %s:", key)}
+	for _, difference := range differences {
+		lines = append(lines, fmt.Sprintf("    %s: %s != %s", difference.path, difference.want, difference.got))
+	}
+	return lines
+}
+
+// visit is a pair of pointers, maps or slices of the same type that valueDiff has compared.
+type visit struct {
+	got  uintptr
+	len  int
+	typ  reflect.Type
+	want uintptr
+}
+
+// valueDiff returns the paths within the values at which they differ. Pairs of pointers, maps and slices
+// are compared once, so that values holding cycles, such as a struct that points to itself, are compared
+// in the same way as by reflect.DeepEqual instead of recursing forever.
+func valueDiff(path string, want reflect.Value, got reflect.Value, visited map[visit]bool) []difference {
+	if !want.IsValid() || !got.IsValid() || want.Type() != got.Type() {
+		if want.IsValid() == got.IsValid() && (!want.IsValid() || reflect.DeepEqual(valueOf(want), valueOf(got))) {
+			return nil
+		}
+		return []difference{{formatValue(got), path, formatValue(want)}}
+	}
+	switch want.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		key := visit{got: got.Pointer(), typ: want.Type(), want: want.Pointer()}
+		if want.Kind() == reflect.Slice {
+			key.len = want.Len()
+		}
+		if key.want != 0 && key.got != 0 {
+			if visited[key] {
+				return nil
+			}
+			visited[key] = true
+		}
+	}
+	switch want.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !want.IsNil() && !got.IsNil() {
+			return valueDiff(path, want.Elem(), got.Elem(), visited)
+		}
+	case reflect.Struct:
+		var differences []difference
+		for i := 0; i < want.NumField(); i++ {
+			differences = append(differences, valueDiff(path+"."+want.Type().Field(i).Name, want.Field(i), got.Field(i), visited)...)
+		}
+		return differences
+	case reflect.Slice, reflect.Array:
+		if want.Kind() == reflect.Slice && want.IsNil() != got.IsNil() {
+			break
+		}
+		var differences []difference
+		for i := 0; i < max(want.Len(), got.Len()); i++ {
+			index := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= got.Len():
+				differences = append(differences, difference{missing, index, formatValue(want.Index(i))})
+			case i >= want.Len():
+				differences = append(differences, difference{formatValue(got.Index(i)), index, missing})
+			default:
+				differences = append(differences, valueDiff(index, want.Index(i), got.Index(i), visited)...)
+			}
+		}
+		return differences
+	case reflect.Map:
+		if want.IsNil() != got.IsNil() {
+			break
+		}
+		keys := map[string]reflect.Value{}
+		for _, key := range append(want.MapKeys(), got.MapKeys()...) {
+			keys[formatValue(key)] = key
+		}
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var differences []difference
+		for _, name := range names {
+			index := path + "[" + name + "]"
+			wantValue, gotValue := want.MapIndex(keys[name]), got.MapIndex(keys[name])
+			switch {
+			case !gotValue.IsValid():
+				differences = append(differences, difference{missing, index, formatValue(wantValue)})
+			case !wantValue.IsValid():
+				differences = append(differences, difference{formatValue(gotValue), index, missing})
+			default:
+				differences = append(differences, valueDiff(index, wantValue, gotValue, visited)...)
+			}
+		}
+		return differences
+	}
+	if reflect.DeepEqual(valueOf(want), valueOf(got)) {
+		return nil
+	}
+	return []difference{{formatValue(got), path, formatValue(want)}}
+}
+
+// isNil checks if the value is nil or holds a nil pointer, map, slice, function or channel.
+func isNil(value any) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
+
+// valueOf returns the value held by v, or its formatted representation if it holds an unexported field.
+func valueOf(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return fmt.Sprintf("%#v", v)
+}
+
+// format formats a key or value, quoting strings.
+func format(value any) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	return fmt.Sprintf("%+v", value)
+}
+
+// formatValue formats a reflected key or value, quoting strings.
+func formatValue(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>"
+	}
+	if v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	return fmt.Sprintf("%+v", v)
+}
+
+// indent joins the lines, indenting each one.
+func indent(lines []string) string {
+	return "    " + strings.Join(lines, "\n    ")
+}
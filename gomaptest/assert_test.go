@@ -0,0 +1,137 @@
+package gomaptest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+	"github.com/lindsaygelle/gomap/gomaptest"
+)
+
+// account is a value with nested fields used by the assertion tests.
+type account struct {
+	Name  string
+	Roles []string
+	Meta  map[string]int
+	Owner *account
+}
+
+// version is a value that implements gomap.Equaler with a pointer receiver.
+type version struct {
+	Major int
+}
+
+// Equal checks if the versions have the same major version.
+func (value *version) Equal(other *version) bool {
+	return value.Major == other.Major
+}
+
+// TestAssertEqual tests AssertEqual.
+func TestAssertEqual(t *testing.T) {
+	want := &gomap.Map[string, int]{"a": 1, "b": 2, "c": 3}
+	if !gomaptest.AssertEqual(t, want, &gomap.Map[string, int]{"c": 3, "b": 2, "a": 1}) {
+		t.Errorf("Expected equal maps to pass")
+	}
+	recorder := &recorder{TB: t}
+	if gomaptest.AssertEqual(recorder, want, &gomap.Map[string, int]{"b": 20, "c": 3, "d": 4}) {
+		t.Errorf("Expected different maps to fail")
+	}
+	expected := `gomaptest: maps are not equal (-want +got):
+    - "a": 1
+    ~ "b": 2 != 20
+    + "d": 4`
+	if len(recorder.messages) != 1 || recorder.messages[0] != expected {
+		t.Errorf("Expected %s, but got %v", expected, recorder.messages)
+	}
+}
+
+// TestAssertEqualNested tests that AssertEqual reports the fields, elements and keys of changed values.
+func TestAssertEqualNested(t *testing.T) {
+	owner := &account{Name: "root"}
+	want := &gomap.Map[int, account]{1: {Name: "ada", Roles: []string{"admin", "dev"}, Meta: map[string]int{"x": 1, "y": 2}, Owner: owner}}
+	got := &gomap.Map[int, account]{1: {Name: "bob", Roles: []string{"admin"}, Meta: map[string]int{"x": 1, "z": 3}, Owner: &account{Name: "sys"}}}
+	recorder := &recorder{TB: t}
+	gomaptest.AssertEqual(recorder, want, got)
+	expected := `gomaptest: maps are not equal (-want +got):
+    ~ 1:
+        .Name: "ada" != "bob"
+        .Roles[1]: "dev" != <missing>
+        .Meta["y"]: 2 != <missing>
+        .Meta["z"]: <missing> != 3
+        .Owner.Name: "root" != "sys"`
+	if len(recorder.messages) != 1 || recorder.messages[0] != expected {
+		t.Errorf("Expected %s, but got %v", expected, recorder.messages)
+	}
+}
+
+// TestAssertEqualCycle tests that AssertEqual reports differences in values that refer to themselves.
+func TestAssertEqualCycle(t *testing.T) {
+	wantOwner, gotOwner := &account{Name: "root"}, &account{Name: "sys"}
+	wantOwner.Owner, gotOwner.Owner = wantOwner, gotOwner
+	recorder := &recorder{TB: t}
+	gomaptest.AssertEqual(recorder, &gomap.Map[int, *account]{1: wantOwner}, &gomap.Map[int, *account]{1: gotOwner})
+	if len(recorder.messages) != 1 || !strings.HasSuffix(recorder.messages[0], `~ 1:
+        .Name: "root" != "sys"`) {
+		t.Errorf("Expected a single difference in Name, but got %v", recorder.messages)
+	}
+	same := &account{Name: "root"}
+	same.Owner = same
+	if !gomaptest.AssertSubset(t, &gomap.Map[int, *account]{1: wantOwner}, &gomap.Map[int, *account]{1: same}) {
+		t.Errorf("Expected equal cyclic values to pass")
+	}
+}
+
+// TestAssertEqualNilEqualer tests that the default comparison does not call Equal on nil values.
+func TestAssertEqualNilEqualer(t *testing.T) {
+	want := &gomap.Map[string, *version]{"a": nil, "b": {1}}
+	if !gomaptest.AssertEqual(t, want, &gomap.Map[string, *version]{"a": nil, "b": {1}}) {
+		t.Errorf("Expected nil values to be equal")
+	}
+	recorder := &recorder{TB: t}
+	if gomaptest.AssertEqual(recorder, want, &gomap.Map[string, *version]{"a": {1}, "b": nil}) {
+		t.Errorf("Expected nil and non-nil values to differ")
+	}
+}
+
+// TestAssertEqualFunc tests AssertEqual with WithEqualFunc.
+func TestAssertEqualFunc(t *testing.T) {
+	want := &gomap.Map[string, string]{"a": "Apple", "b": "Banana"}
+	got := &gomap.Map[string, string]{"a": "APPLE", "b": "banana"}
+	if !gomaptest.AssertEqual(t, want, got, gomaptest.WithEqualFunc(strings.EqualFold)) {
+		t.Errorf("Expected values equal ignoring case to pass")
+	}
+	recorder := &recorder{TB: t}
+	if gomaptest.AssertEqual(recorder, want, got) || !strings.Contains(recorder.messages[0], `~ "a": "Apple" != "APPLE"`) {
+		t.Errorf("Expected the default comparison to fail, but got %v", recorder.messages)
+	}
+}
+
+// TestAssertContainsKeys tests AssertContainsKeys.
+func TestAssertContainsKeys(t *testing.T) {
+	got := &gomap.Map[string, int]{"a": 1, "b": 2}
+	if !gomaptest.AssertContainsKeys(t, got, "a", "b") {
+		t.Errorf("Expected present keys to pass")
+	}
+	recorder := &recorder{TB: t}
+	gomaptest.AssertContainsKeys(recorder, got, "d", "a", "c")
+	expected := "gomaptest: map is missing 2 of 3 keys:\n    - \"c\"\n    - \"d\""
+	if len(recorder.messages) != 1 || recorder.messages[0] != expected {
+		t.Errorf("Expected %s, but got %v", expected, recorder.messages)
+	}
+}
+
+// TestAssertSubset tests AssertSubset.
+func TestAssertSubset(t *testing.T) {
+	got := &gomap.Map[string, int]{"a": 1, "b": 2, "c": 3}
+	if !gomaptest.AssertSubset(t, &gomap.Map[string, int]{"a": 1, "c": 3}, got) {
+		t.Errorf("Expected a subset to pass")
+	}
+	recorder := &recorder{TB: t}
+	gomaptest.AssertSubset(recorder, &gomap.Map[string, int]{"a": 1, "b": 5, "d": 4}, got)
+	expected := `gomaptest: map is not a superset (-want +got):
+    ~ "b": 5 != 2
+    - "d": 4`
+	if len(recorder.messages) != 1 || recorder.messages[0] != expected {
+		t.Errorf("Expected %s, but got %v", expected, recorder.messages)
+	}
+}
@@ -0,0 +1,96 @@
+package gomaptest
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Default settings used when a Config is nil or leaves a field as zero.
+const (
+	DefaultMaxShrinks = 1000
+	DefaultRuns       = 100
+)
+
+// Config controls how Check runs a property.
+type Config struct {
+	// MaxShrinks limits the number of successful shrinking steps. It defaults to DefaultMaxShrinks.
+	MaxShrinks int
+	// Runs is the number of generated inputs to check. It defaults to DefaultRuns.
+	Runs int
+	// Seed seeds the generators. If zero, the current time is used; the seed is reported on failure
+	// so that the failing run can be reproduced.
+	Seed int64
+}
+
+// Check runs the property against arity values drawn from the generator, repeating for the configured
+// number of runs. If the property returns an error, Check shrinks the failing values using the shrinker,
+// keeping each smaller candidate for which the property still fails, reports the smallest counterexample
+// found with t.Errorf and returns false. A nil shrinker disables shrinking. A nil Config uses the defaults.
+// The property must not modify its arguments.
+func Check[T any](t testing.TB, config *Config, generator Generator[T], shrinker Shrinker[T], arity int, property func(values ...T) error) bool {
+	t.Helper()
+	if config == nil {
+		config = &Config{}
+	}
+	runs, maxShrinks, seed := config.Runs, config.MaxShrinks, config.Seed
+	if runs <= 0 {
+		runs = DefaultRuns
+	}
+	if maxShrinks <= 0 {
+		maxShrinks = DefaultMaxShrinks
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	random := rand.New(rand.NewSource(seed))
+	for run := 1; run <= runs; run++ {
+		values := make([]T, arity)
+		for i := range values {
+			values[i] = generator(random)
+		}
+		err := property(values...)
+		if err == nil {
+			continue
+		}
+		original := fmt.Sprint(values)
+		values, err, shrinks := shrink(values, err, shrinker, maxShrinks, property)
+		var message strings.Builder
+		fmt.Fprintf(&message, "gomaptest: property failed on run %d (seed %d, %d shrinks): %v", run, seed, shrinks, err)
+		for i, value := range values {
+			fmt.Fprintf(&message, "\n\tvalue %d: %v", i, value)
+		}
+		if shrinks > 0 {
+			fmt.Fprintf(&message, "\n\toriginal: %s", original)
+		}
+		t.Errorf("%s", message.String())
+		return false
+	}
+	return true
+}
+
+// shrink replaces the values with smaller candidates while the property still fails,
+// returning the smallest failing values, their error and the number of shrinking steps taken.
+func shrink[T any](values []T, err error, shrinker Shrinker[T], maxShrinks int, property func(values ...T) error) ([]T, error, int) {
+	if shrinker == nil {
+		return values, err, 0
+	}
+	shrinks := 0
+	for improved := true; improved && shrinks < maxShrinks; {
+		improved = false
+		for i := 0; i < len(values) && !improved; i++ {
+			for _, candidate := range shrinker(values[i]) {
+				trial := append([]T(nil), values...)
+				trial[i] = candidate
+				if trialErr := property(trial...); trialErr != nil {
+					values, err, improved = trial, trialErr, true
+					shrinks++
+					break
+				}
+			}
+		}
+	}
+	return values, err, shrinks
+}
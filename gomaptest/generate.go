@@ -0,0 +1,163 @@
+// Package gomaptest provides helpers for property-based testing of code that uses gomap.Map.
+//
+// Generators produce seeded random values and maps, Check runs a property against many generated inputs
+// and shrinks the inputs of a failing run to a smaller counterexample, and Laws describe algebraic properties
+// such as associativity that can be checked for Map or for any Map-like type.
+//
+//	func TestMergeLaws(t *testing.T) {
+//		generator := gomaptest.Maps(gomaptest.Strings("abc", 2), gomaptest.Ints(0, 9), 0, 8)
+//		shrinker := gomaptest.ShrinkMap[string](gomaptest.ShrinkInt)
+//		for _, law := range gomaptest.MapLaws[string, int](nil) {
+//			gomaptest.CheckLaw(t, nil, generator, shrinker, law)
+//		}
+//	}
+package gomaptest
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// Generator returns a random value drawn from the source of randomness.
+type Generator[T any] func(random *rand.Rand) T
+
+// Shrinker returns smaller candidates for a value, most aggressive first, that Check tries in place of a value
+// that made a property fail. It returns no candidates once the value cannot be shrunk any further.
+type Shrinker[T any] func(value T) []T
+
+// Ints returns a Generator of integers in the range [min, max]. It panics if min is greater than max.
+func Ints(min int, max int) Generator[int] {
+	if min > max {
+		panic(fmt.Sprintf("gomaptest: Ints: min %d is greater than max %d", min, max))
+	}
+	width := uint64(max) - uint64(min)
+	if width < math.MaxInt {
+		return func(random *rand.Rand) int {
+			return min + random.Intn(int(width)+1)
+		}
+	}
+	// The range holds more integers than Intn can draw from, so draw offsets from min by rejection sampling.
+	mask := uint64(math.MaxUint64) >> bits.LeadingZeros64(width)
+	return func(random *rand.Rand) int {
+		for {
+			if offset := random.Uint64() & mask; offset <= width {
+				return int(uint64(min) + offset)
+			}
+		}
+	}
+}
+
+// OneOf returns a Generator that picks one of the values. It panics if no values are provided.
+func OneOf[T any](values ...T) Generator[T] {
+	if len(values) == 0 {
+		panic("gomaptest: OneOf: no values")
+	}
+	return func(random *rand.Rand) T {
+		return values[random.Intn(len(values))]
+	}
+}
+
+// Strings returns a Generator of strings of up to maxLength bytes drawn from the alphabet.
+// It panics if the alphabet is empty or maxLength is negative.
+func Strings(alphabet string, maxLength int) Generator[string] {
+	if alphabet == "" {
+		panic("gomaptest: Strings: empty alphabet")
+	}
+	if maxLength < 0 {
+		panic(fmt.Sprintf("gomaptest: Strings: negative maxLength %d", maxLength))
+	}
+	return func(random *rand.Rand) string {
+		value := make([]byte, random.Intn(maxLength+1))
+		for i := range value {
+			value[i] = alphabet[random.Intn(len(alphabet))]
+		}
+		return string(value)
+	}
+}
+
+// Maps returns a Generator of maps holding between minSize and maxSize entries whose keys and values
+// are drawn from the key and value generators. Maps may hold fewer than minSize entries if the key
+// generator cannot produce enough distinct keys. It panics if minSize is negative or greater than maxSize.
+func Maps[K comparable, V any](keys Generator[K], values Generator[V], minSize int, maxSize int) Generator[*gomap.Map[K, V]] {
+	if minSize < 0 || minSize > maxSize {
+		panic(fmt.Sprintf("gomaptest: Maps: invalid size range [%d, %d]", minSize, maxSize))
+	}
+	return func(random *rand.Rand) *gomap.Map[K, V] {
+		size := minSize + random.Intn(maxSize-minSize+1)
+		newMap := make(gomap.Map[K, V], size)
+		for attempts := 0; newMap.Length() < size && attempts < size*10; attempts++ {
+			newMap.Add(keys(random), values(random))
+		}
+		return &newMap
+	}
+}
+
+// ShrinkInt shrinks an integer towards zero.
+func ShrinkInt(value int) []int {
+	if value == 0 {
+		return nil
+	}
+	candidates := []int{0}
+	if half := value / 2; half != 0 {
+		candidates = append(candidates, half)
+	}
+	step := 1
+	if value < 0 {
+		step = -1
+	}
+	// Stepping towards zero cannot overflow, unlike negating math.MinInt.
+	if next := value - step; next != 0 && next != value/2 {
+		candidates = append(candidates, next)
+	}
+	return candidates
+}
+
+// ShrinkString shrinks a string towards the empty string by removing bytes.
+func ShrinkString(value string) []string {
+	if value == "" {
+		return nil
+	}
+	candidates := []string{""}
+	if len(value) > 1 {
+		candidates = append(candidates, value[:len(value)/2], value[len(value)/2:])
+	}
+	for i := range value {
+		candidates = append(candidates, value[:i]+value[i+1:])
+	}
+	return candidates
+}
+
+// ShrinkMap returns a Shrinker of maps that tries the empty map, then the map without each of its keys,
+// then the map with each of its values replaced by the candidates of the value shrinker.
+// If the value shrinker is nil, values are not shrunk.
+func ShrinkMap[K comparable, V any](values Shrinker[V]) Shrinker[*gomap.Map[K, V]] {
+	return func(value *gomap.Map[K, V]) []*gomap.Map[K, V] {
+		if value.IsEmpty() {
+			return nil
+		}
+		candidates := []*gomap.Map[K, V]{{}}
+		value.Each(func(key K, _ V) {
+			candidates = append(candidates, clone(value).Delete(key))
+		})
+		if values == nil {
+			return candidates
+		}
+		value.Each(func(key K, v V) {
+			for _, candidate := range values(v) {
+				candidates = append(candidates, clone(value).Add(key, candidate))
+			}
+		})
+		return candidates
+	}
+}
+
+// clone returns a copy of the map.
+func clone[K comparable, V any](value *gomap.Map[K, V]) *gomap.Map[K, V] {
+	newMap := make(gomap.Map[K, V], value.Length())
+	newMap.AddMany(*value)
+	return &newMap
+}
@@ -0,0 +1,194 @@
+package gomaptest_test
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+	"github.com/lindsaygelle/gomap/gomaptest"
+)
+
+// recorder is a testing.TB that records failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	messages []string
+}
+
+func (recorder *recorder) Errorf(format string, args ...any) {
+	recorder.messages = append(recorder.messages, fmt.Sprintf(format, args...))
+}
+
+func (recorder *recorder) Helper() {}
+
+// TestMaps tests Maps.
+func TestMaps(t *testing.T) {
+	generator := gomaptest.Maps(gomaptest.Ints(0, 99), gomaptest.Strings("ab", 3), 2, 5)
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		newMap := generator(random)
+		if newMap.Length() < 2 || newMap.Length() > 5 {
+			t.Fatalf("Expected between 2 and 5 entries, but got %v", newMap)
+		}
+		newMap.Each(func(key int, value string) {
+			if key < 0 || key > 99 || len(value) > 3 || strings.Trim(value, "ab") != "" {
+				t.Fatalf("Expected generated key and value in range, but got %d: %q", key, value)
+			}
+		})
+	}
+	a, b := generator(rand.New(rand.NewSource(7))), generator(rand.New(rand.NewSource(7)))
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected the same seed to generate %v, but got %v", a, b)
+	}
+}
+
+// TestInts tests Ints at the limits of the int range.
+func TestInts(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	tests := [][2]int{{math.MinInt, math.MaxInt}, {-1, math.MaxInt}, {math.MinInt, 0}, {math.MaxInt, math.MaxInt}, {-3, -3}}
+	for _, test := range tests {
+		generator := gomaptest.Ints(test[0], test[1])
+		for i := 0; i < 100; i++ {
+			if value := generator(random); value < test[0] || value > test[1] {
+				t.Fatalf("Expected Ints(%d, %d) to generate a value in range, but got %d", test[0], test[1], value)
+			}
+		}
+	}
+}
+
+// TestGeneratorPanics tests that generators panic on invalid arguments.
+func TestGeneratorPanics(t *testing.T) {
+	tests := map[string]func(){
+		"Ints min > max":     func() { gomaptest.Ints(1, 0) },
+		"OneOf no values":    func() { gomaptest.OneOf[int]() },
+		"Strings alphabet":   func() { gomaptest.Strings("", 3) },
+		"Strings length":     func() { gomaptest.Strings("ab", -1) },
+		"Maps maxSize":       func() { gomaptest.Maps(gomaptest.Ints(0, 9), gomaptest.Ints(0, 9), 3, 2) },
+		"Maps negative size": func() { gomaptest.Maps(gomaptest.Ints(0, 9), gomaptest.Ints(0, 9), -1, 2) },
+	}
+	for name, fn := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r == nil || !strings.HasPrefix(fmt.Sprint(r), "gomaptest: ") {
+					t.Errorf("%s: expected a descriptive panic, but got %v", name, r)
+				}
+			}()
+			fn()
+		}()
+	}
+}
+
+// TestShrinkInt tests ShrinkInt.
+func TestShrinkInt(t *testing.T) {
+	tests := map[int][]int{0: nil, 1: {0}, -1: {0}, 2: {0, 1}, 10: {0, 5, 9}, -10: {0, -5, -9},
+		math.MinInt: {0, math.MinInt / 2, math.MinInt + 1}, math.MaxInt: {0, math.MaxInt / 2, math.MaxInt - 1}}
+	for value, expected := range tests {
+		if result := gomaptest.ShrinkInt(value); !reflect.DeepEqual(result, expected) {
+			t.Errorf("Expected ShrinkInt(%d) to be %v, but got %v", value, expected, result)
+		}
+	}
+}
+
+// TestShrinkMap tests ShrinkMap.
+func TestShrinkMap(t *testing.T) {
+	shrinker := gomaptest.ShrinkMap[string](gomaptest.ShrinkInt)
+	if candidates := shrinker(&gomap.Map[string, int]{}); len(candidates) != 0 {
+		t.Errorf("Expected no candidates for an empty map, but got %v", candidates)
+	}
+	candidates := shrinker(&gomap.Map[string, int]{"a": 2, "b": 0})
+	expected := []string{"map[]", "map[a:0 b:0]", "map[a:1 b:0]", "map[a:2]", "map[b:0]"}
+	var results []string
+	for _, candidate := range candidates {
+		results = append(results, fmt.Sprint(*candidate))
+	}
+	if len(results) == 0 || results[0] != "map[]" {
+		t.Errorf("Expected the empty map to be tried first, but got %v", results)
+	}
+	sort.Strings(results)
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %v, but got %v", expected, results)
+	}
+}
+
+// TestCheck tests that Check shrinks a failing input to a minimal counterexample.
+func TestCheck(t *testing.T) {
+	recorder := &recorder{TB: t}
+	generator := gomaptest.Maps(gomaptest.Strings("abc", 3), gomaptest.Ints(0, 100), 0, 10)
+	ok := gomaptest.Check(recorder, &gomaptest.Config{Seed: 1}, generator, gomaptest.ShrinkMap[string](gomaptest.ShrinkInt), 1,
+		func(values ...*gomap.Map[string, int]) error {
+			for _, value := range *values[0] {
+				if value > 50 {
+					return fmt.Errorf("found %d", value)
+				}
+			}
+			return nil
+		})
+	if ok || len(recorder.messages) != 1 {
+		t.Fatalf("Expected one failure, but got %v", recorder.messages)
+	}
+	message := recorder.messages[0]
+	if !strings.Contains(message, "seed 1") || !strings.Contains(message, "found 51") || !strings.Contains(message, "original:") || strings.Count(message, ":51") != 1 {
+		t.Errorf("Expected the failure to report the seed and a shrunk value, but got %s", message)
+	}
+}
+
+// TestCheckPass tests that Check returns true when the property holds.
+func TestCheckPass(t *testing.T) {
+	recorder := &recorder{TB: t}
+	runs := 0
+	ok := gomaptest.Check(recorder, &gomaptest.Config{Runs: 25}, gomaptest.Ints(0, 9), nil, 2, func(values ...int) error {
+		runs++
+		if len(values) != 2 {
+			return fmt.Errorf("got %d values", len(values))
+		}
+		return nil
+	})
+	if !ok || runs != 25 || len(recorder.messages) != 0 {
+		t.Errorf("Expected 25 passing runs, but got %d runs and %v", runs, recorder.messages)
+	}
+}
+
+// TestMapLaws tests that Map satisfies MapLaws.
+func TestMapLaws(t *testing.T) {
+	generator := gomaptest.Maps(gomaptest.Strings("abc", 2), gomaptest.Ints(0, 3), 0, 8)
+	shrinker := gomaptest.ShrinkMap[string](gomaptest.ShrinkInt)
+	for _, law := range gomaptest.MapLaws[string, int](nil) {
+		gomaptest.CheckLaw(t, &gomaptest.Config{Seed: 1}, generator, shrinker, law)
+	}
+}
+
+// TestLaws tests that laws detect operations that break them.
+func TestLaws(t *testing.T) {
+	equal := func(a, b int) bool { return a == b }
+	tests := []struct {
+		law      gomaptest.Law[int]
+		expected bool
+	}{
+		{gomaptest.Associative("add", func(a, b int) int { return a + b }, equal), true},
+		{gomaptest.Associative("subtract", func(a, b int) int { return a - b }, equal), false},
+		{gomaptest.Commutative("max", func(a, b int) int { return max(a, b) }, equal), true},
+		{gomaptest.Commutative("first", func(a, b int) int { return a }, equal), false},
+		{gomaptest.Idempotent("abs", func(a int) int { return max(a, -a) }, equal), true},
+		{gomaptest.Idempotent("increment", func(a int) int { return a + 1 }, equal), false},
+	}
+	for _, test := range tests {
+		recorder := &recorder{TB: t}
+		if ok := gomaptest.CheckLaw(recorder, &gomaptest.Config{Seed: 1}, gomaptest.Ints(-9, 9), gomaptest.ShrinkInt, test.law); ok != test.expected {
+			t.Errorf("Expected %s to return %v, but got %v", test.law.Name, test.expected, recorder.messages)
+		}
+	}
+	recorder := &recorder{TB: t}
+	merge := func(a, b *gomap.Map[string, int]) *gomap.Map[string, int] {
+		newMap := make(gomap.Map[string, int])
+		return newMap.Merge(a).Merge(b)
+	}
+	gomaptest.CheckLaw(recorder, &gomaptest.Config{Seed: 1}, gomaptest.Maps(gomaptest.OneOf("a", "b"), gomaptest.Ints(0, 9), 1, 2),
+		gomaptest.ShrinkMap[string](gomaptest.ShrinkInt), gomaptest.Commutative("Merge", merge, (*gomap.Map[string, int]).Equal))
+	if len(recorder.messages) != 1 || !strings.Contains(recorder.messages[0], "value 0: &map[") {
+		t.Errorf("Expected Merge not to be commutative, but got %v", recorder.messages)
+	}
+}
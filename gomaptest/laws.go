@@ -0,0 +1,84 @@
+package gomaptest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// Law is a named property over a fixed number of values, such as the associativity of an operation.
+type Law[T any] struct {
+	Arity    int
+	Name     string
+	Property func(values ...T) error
+}
+
+// Associative returns a Law checking that op(op(a, b), c) equals op(a, op(b, c)).
+// The operation must not modify its arguments.
+func Associative[T any](name string, op func(a T, b T) T, equal func(a T, b T) bool) Law[T] {
+	return Law[T]{Arity: 3, Name: name, Property: func(values ...T) error {
+		a, b, c := values[0], values[1], values[2]
+		left, right := op(op(a, b), c), op(a, op(b, c))
+		if !equal(left, right) {
+			return fmt.Errorf("%s: (a op b) op c = %v, but a op (b op c) = %v", name, left, right)
+		}
+		return nil
+	}}
+}
+
+// Commutative returns a Law checking that op(a, b) equals op(b, a).
+// The operation must not modify its arguments.
+func Commutative[T any](name string, op func(a T, b T) T, equal func(a T, b T) bool) Law[T] {
+	return Law[T]{Arity: 2, Name: name, Property: func(values ...T) error {
+		a, b := values[0], values[1]
+		left, right := op(a, b), op(b, a)
+		if !equal(left, right) {
+			return fmt.Errorf("%s: a op b = %v, but b op a = %v", name, left, right)
+		}
+		return nil
+	}}
+}
+
+// Idempotent returns a Law checking that fn(fn(a)) equals fn(a).
+// The function must not modify its argument.
+func Idempotent[T any](name string, fn func(a T) T, equal func(a T, b T) bool) Law[T] {
+	return Law[T]{Arity: 1, Name: name, Property: func(values ...T) error {
+		once := fn(values[0])
+		if twice := fn(once); !equal(once, twice) {
+			return fmt.Errorf("%s: f(a) = %v, but f(f(a)) = %v", name, once, twice)
+		}
+		return nil
+	}}
+}
+
+// CheckLaw checks the law using Check, naming the law in the failure report.
+func CheckLaw[T any](t testing.TB, config *Config, generator Generator[T], shrinker Shrinker[T], law Law[T]) bool {
+	t.Helper()
+	return Check(t, config, generator, shrinker, law.Arity, law.Property)
+}
+
+// MapLaws returns the laws that Map is expected to satisfy: Merge is associative, Intersection is commutative
+// and Filter is idempotent. The filter function is used by the Filter law; if nil, it keeps the keys
+// whose formatted representation has an even length.
+func MapLaws[K comparable, V any](filter func(key K, value V) bool) []Law[*gomap.Map[K, V]] {
+	if filter == nil {
+		filter = func(key K, value V) bool {
+			return len(fmt.Sprint(key))%2 == 0
+		}
+	}
+	equal := func(a, b *gomap.Map[K, V]) bool {
+		return a.Equal(b)
+	}
+	return []Law[*gomap.Map[K, V]]{
+		Associative("Merge", func(a, b *gomap.Map[K, V]) *gomap.Map[K, V] {
+			return clone(a).Merge(b)
+		}, equal),
+		Commutative("Intersection", func(a, b *gomap.Map[K, V]) *gomap.Map[K, V] {
+			return a.Intersection(b)
+		}, equal),
+		Idempotent("Filter", func(a *gomap.Map[K, V]) *gomap.Map[K, V] {
+			return a.Filter(filter)
+		}, equal),
+	}
+}
@@ -0,0 +1,136 @@
+package gomap
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+)
+
+// hashSeed is the process-wide seed used by the default Hasher.
+var hashSeed = maphash.MakeSeed()
+
+// Hasher computes a 64-bit hash for keys of type K.
+// Keys that are equal according to == must produce the same hash.
+type Hasher[K comparable] interface {
+	Hash(key K) uint64
+}
+
+// HasherFunc is an adapter that allows an ordinary function to be used as a Hasher.
+//
+//	// Create a new Hasher from a function.
+//	hasher := gomap.HasherFunc[string](func(key string) uint64 {
+//		return uint64(len(key))
+//	})
+//	hash := hasher.Hash("apple") // 5
+type HasherFunc[K comparable] func(key K) uint64
+
+// Hash calls fn(key).
+func (fn HasherFunc[K]) Hash(key K) uint64 {
+	return fn(key)
+}
+
+// defaultHasher hashes built-in scalar key types directly and falls back to hashing the
+// fields and elements of all other comparable types using reflection.
+type defaultHasher[K comparable] struct{}
+
+// Hash returns the hash of the provided key.
+func (defaultHasher[K]) Hash(key K) uint64 {
+	var b [8]byte
+	switch k := any(key).(type) {
+	case string:
+		return maphash.String(hashSeed, k)
+	case int:
+		binary.LittleEndian.PutUint64(b[:], uint64(k))
+	case int8:
+		binary.LittleEndian.PutUint64(b[:], uint64(k))
+	case int16:
+		binary.LittleEndian.PutUint64(b[:], uint64(k))
+	case int32:
+		binary.LittleEndian.PutUint64(b[:], uint64(k))
+	case int64:
+		binary.LittleEndian.PutUint64(b[:], uint64(k))
+	case uint:
+		binary.LittleEndian.PutUint64(b[:], uint64(k))
+	case uint8:
+		binary.LittleEndian.PutUint64(b[:], uint64(k))
+	case uint16:
+		binary.LittleEndian.PutUint64(b[:], uint64(k))
+	case uint32:
+		binary.LittleEndian.PutUint64(b[:], uint64(k))
+	case uint64:
+		binary.LittleEndian.PutUint64(b[:], k)
+	case uintptr:
+		binary.LittleEndian.PutUint64(b[:], uint64(k))
+	case float32:
+		if k != 0 { // +0 and -0 are equal keys.
+			binary.LittleEndian.PutUint64(b[:], uint64(math.Float32bits(k)))
+		}
+	case float64:
+		if k != 0 { // +0 and -0 are equal keys.
+			binary.LittleEndian.PutUint64(b[:], math.Float64bits(k))
+		}
+	case bool:
+		if k {
+			b[0] = 1
+		}
+	default:
+		var hash maphash.Hash
+		hash.SetSeed(hashSeed)
+		hashValue(&hash, reflect.ValueOf(&key).Elem())
+		return hash.Sum64()
+	}
+	return maphash.Bytes(hashSeed, b[:])
+}
+
+// hashValue writes the value to the hash so that values that are equal according to == write the same bytes.
+// Floating-point zeros are normalized because +0 and -0 are equal, and interfaces write their dynamic type
+// before their value.
+func hashValue(hash *maphash.Hash, value reflect.Value) {
+	var b [8]byte
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			b[0] = 1
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		binary.LittleEndian.PutUint64(b[:], uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		binary.LittleEndian.PutUint64(b[:], value.Uint())
+	case reflect.Float32, reflect.Float64:
+		if f := value.Float(); f != 0 { // +0 and -0 are equal keys.
+			binary.LittleEndian.PutUint64(b[:], math.Float64bits(f))
+		}
+	case reflect.Complex64, reflect.Complex128:
+		c := value.Complex()
+		hashValue(hash, reflect.ValueOf(real(c)))
+		hashValue(hash, reflect.ValueOf(imag(c)))
+		return
+	case reflect.String:
+		hash.WriteString(value.String())
+		hash.WriteByte(0)
+		return
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		binary.LittleEndian.PutUint64(b[:], uint64(value.Pointer()))
+	case reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			hashValue(hash, value.Index(i))
+		}
+		return
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			hashValue(hash, value.Field(i))
+		}
+		return
+	case reflect.Interface:
+		if value.IsNil() {
+			hash.WriteByte(0)
+			return
+		}
+		hash.WriteByte(1)
+		hash.WriteString(value.Elem().Type().String())
+		hashValue(hash, value.Elem())
+		return
+	}
+	hash.Write(b[:])
+}
@@ -0,0 +1,77 @@
+package gomap
+
+import (
+	"cmp"
+	"sync"
+	"time"
+)
+
+// HLC is a hybrid logical clock timestamp. It combines a physical wall time with a logical counter that orders
+// events sharing the same wall time, and the name of the node that created it so that timestamps from different
+// nodes are never equal. Timestamps are totally ordered by Wall, then Logical, then Node.
+type HLC struct {
+	Wall    int64  `json:"wall"`
+	Logical uint32 `json:"logical"`
+	Node    string `json:"node"`
+}
+
+// Compare returns -1 if the timestamp is before the other, 1 if it is after it, and 0 if they are equal.
+func (hlc HLC) Compare(other HLC) int {
+	if compare := cmp.Compare(hlc.Wall, other.Wall); compare != 0 {
+		return compare
+	}
+	if compare := cmp.Compare(hlc.Logical, other.Logical); compare != 0 {
+		return compare
+	}
+	return cmp.Compare(hlc.Node, other.Node)
+}
+
+// Before checks if the timestamp is ordered before the other.
+func (hlc HLC) Before(other HLC) bool {
+	return hlc.Compare(other) < 0
+}
+
+// Clock issues hybrid logical clock timestamps for a node. Timestamps issued by a Clock always increase,
+// even if the physical clock goes backwards, and are ordered after every timestamp the Clock has observed.
+// A Clock is safe for concurrent use.
+type Clock struct {
+	last  HLC
+	mutex sync.Mutex
+	// Physical returns the current physical time. It defaults to time.Now.
+	Physical func() time.Time
+}
+
+// NewClock creates a Clock for the named node. Each node must have a unique name.
+//
+//	// Create a new Clock instance.
+//	clock := gomap.NewClock("replica-1")
+//	a, b := clock.Now(), clock.Now()
+//	ok := a.Before(b) // true
+func NewClock(node string) *Clock {
+	return &Clock{last: HLC{Node: node}, Physical: time.Now}
+}
+
+// Now returns a new timestamp ordered after every timestamp previously issued or observed by the clock.
+func (clock *Clock) Now() HLC {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	if wall := clock.Physical().UnixNano(); wall > clock.last.Wall {
+		clock.last.Wall, clock.last.Logical = wall, 0
+	} else {
+		clock.last.Logical++
+	}
+	return clock.last
+}
+
+// Observe advances the clock past a timestamp received from another node,
+// so that later timestamps issued by the clock are ordered after it.
+func (clock *Clock) Observe(remote HLC) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	switch {
+	case remote.Wall > clock.last.Wall:
+		clock.last.Wall, clock.last.Logical = remote.Wall, remote.Logical
+	case remote.Wall == clock.last.Wall && remote.Logical > clock.last.Logical:
+		clock.last.Logical = remote.Logical
+	}
+}
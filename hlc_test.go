@@ -0,0 +1,74 @@
+package gomap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// newFixedClock returns a Clock for the node whose physical time never advances,
+// so that ordering depends only on the logical counter and node name.
+func newFixedClock(node string) *gomap.Clock {
+	clock := gomap.NewClock(node)
+	clock.Physical = func() time.Time {
+		return time.Unix(0, 1)
+	}
+	return clock
+}
+
+// TestHLCCompare tests HLC.Compare.
+func TestHLCCompare(t *testing.T) {
+	tests := []struct {
+		a, b     gomap.HLC
+		expected int
+	}{
+		{gomap.HLC{Wall: 1}, gomap.HLC{Wall: 2}, -1},
+		{gomap.HLC{Wall: 2, Logical: 0}, gomap.HLC{Wall: 1, Logical: 5}, 1},
+		{gomap.HLC{Wall: 1, Logical: 1}, gomap.HLC{Wall: 1, Logical: 2}, -1},
+		{gomap.HLC{Wall: 1, Logical: 1, Node: "b"}, gomap.HLC{Wall: 1, Logical: 1, Node: "a"}, 1},
+		{gomap.HLC{Wall: 1, Logical: 1, Node: "a"}, gomap.HLC{Wall: 1, Logical: 1, Node: "a"}, 0},
+	}
+	for _, test := range tests {
+		if result := test.a.Compare(test.b); result != test.expected {
+			t.Errorf("Expected %v.Compare(%v) to be %d, but got %d", test.a, test.b, test.expected, result)
+		}
+	}
+}
+
+// TestClockNow tests Clock.Now.
+func TestClockNow(t *testing.T) {
+	clock := gomap.NewClock("a")
+	wall := time.Unix(0, 100)
+	clock.Physical = func() time.Time { return wall }
+	first := clock.Now()
+	if first.Wall != 100 || first.Logical != 0 || first.Node != "a" {
+		t.Errorf("Expected {100 0 a}, but got %v", first)
+	}
+	second := clock.Now()
+	if !first.Before(second) || second.Logical != 1 {
+		t.Errorf("Expected logical counter to advance, but got %v", second)
+	}
+	wall = time.Unix(0, 50) // physical clock moves backwards
+	if third := clock.Now(); !second.Before(third) {
+		t.Errorf("Expected %v to be after %v", third, second)
+	}
+	wall = time.Unix(0, 200)
+	if fourth := clock.Now(); fourth.Wall != 200 || fourth.Logical != 0 {
+		t.Errorf("Expected {200 0 a}, but got %v", fourth)
+	}
+}
+
+// TestClockObserve tests Clock.Observe.
+func TestClockObserve(t *testing.T) {
+	clock := newFixedClock("a")
+	remote := gomap.HLC{Wall: 1000, Logical: 7, Node: "b"}
+	clock.Observe(remote)
+	if now := clock.Now(); !remote.Before(now) {
+		t.Errorf("Expected %v to be after %v", now, remote)
+	}
+	clock.Observe(gomap.HLC{Wall: 1, Node: "c"})
+	if now := clock.Now(); now.Wall != 1000 {
+		t.Errorf("Expected observing an older timestamp to have no effect, but got %v", now)
+	}
+}
@@ -0,0 +1,513 @@
+package gomap
+
+import (
+	"math/bits"
+
+	"github.com/lindsaygelle/slice"
+)
+
+const (
+	hamtBits     = 5                   // Number of hash bits consumed per trie level.
+	hamtMask     = (1 << hamtBits) - 1 // Mask selecting the bits for a single level.
+	hamtMaxShift = 64 - 64%hamtBits    // Shift beyond which hashes are exhausted and keys collide.
+)
+
+// hamtOwner identifies the builder that is allowed to modify a node in place.
+type hamtOwner struct {
+	_ int
+}
+
+// hamtEntry is a single slot of a hamtNode. It either holds a key-value pair or points to a child node.
+type hamtEntry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	node  *hamtNode[K, V]
+	value V
+}
+
+// hamtNode is a node of a hash array mapped trie. Below hamtMaxShift the bitmap records which of the
+// 32 possible slots are populated; beyond it the node is a collision node holding entries linearly.
+type hamtNode[K comparable, V any] struct {
+	bitmap  uint32
+	entries []hamtEntry[K, V]
+	owner   *hamtOwner
+}
+
+// editable returns the node itself if it belongs to the owner, otherwise a copy that does.
+func (node *hamtNode[K, V]) editable(owner *hamtOwner) *hamtNode[K, V] {
+	if owner != nil && node.owner == owner {
+		return node
+	}
+	entries := make([]hamtEntry[K, V], len(node.entries), len(node.entries)+1)
+	copy(entries, node.entries)
+	return &hamtNode[K, V]{bitmap: node.bitmap, entries: entries, owner: owner}
+}
+
+// removeEntry removes the entry at index i from an editable node.
+func (node *hamtNode[K, V]) removeEntry(i int) {
+	last := len(node.entries) - 1
+	copy(node.entries[i:], node.entries[i+1:])
+	node.entries[last] = hamtEntry[K, V]{}
+	node.entries = node.entries[:last]
+}
+
+// hamtIndex returns the bit and slot index used for the hash at the given shift.
+func hamtIndex(bitmap uint32, hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & hamtMask)
+	return bit, bits.OnesCount32(bitmap & (bit - 1))
+}
+
+// hamtGet looks up the key in the trie rooted at node.
+func hamtGet[K comparable, V any](node *hamtNode[K, V], hash uint64, key K) (V, bool) {
+	var shift uint
+	for node != nil {
+		if shift > hamtMaxShift {
+			for _, entry := range node.entries {
+				if entry.key == key {
+					return entry.value, true
+				}
+			}
+			break
+		}
+		bit, i := hamtIndex(node.bitmap, hash, shift)
+		if node.bitmap&bit == 0 {
+			break
+		}
+		entry := node.entries[i]
+		if entry.node == nil {
+			if entry.hash == hash && entry.key == key {
+				return entry.value, true
+			}
+			break
+		}
+		node = entry.node
+		shift += hamtBits
+	}
+	var value V
+	return value, false
+}
+
+// hamtSet associates the key with the value in the trie rooted at node, returning the new root and
+// whether a new key was added. Nodes not owned by owner are copied rather than modified.
+func hamtSet[K comparable, V any](node *hamtNode[K, V], shift uint, entry hamtEntry[K, V], owner *hamtOwner) (*hamtNode[K, V], bool) {
+	if node == nil {
+		node = &hamtNode[K, V]{owner: owner}
+	}
+	if shift > hamtMaxShift {
+		for i, e := range node.entries {
+			if e.key == entry.key {
+				node = node.editable(owner)
+				node.entries[i].value = entry.value
+				return node, false
+			}
+		}
+		node = node.editable(owner)
+		node.entries = append(node.entries, entry)
+		return node, true
+	}
+	bit, i := hamtIndex(node.bitmap, entry.hash, shift)
+	if node.bitmap&bit == 0 {
+		node = node.editable(owner)
+		node.entries = append(node.entries, hamtEntry[K, V]{})
+		copy(node.entries[i+1:], node.entries[i:])
+		node.entries[i] = entry
+		node.bitmap |= bit
+		return node, true
+	}
+	current := node.entries[i]
+	if current.node != nil {
+		child, ok := hamtSet(current.node, shift+hamtBits, entry, owner)
+		node = node.editable(owner)
+		node.entries[i].node = child
+		return node, ok
+	}
+	if current.hash == entry.hash && current.key == entry.key {
+		node = node.editable(owner)
+		node.entries[i].value = entry.value
+		return node, false
+	}
+	child := hamtPair(shift+hamtBits, current, entry, owner)
+	node = node.editable(owner)
+	node.entries[i] = hamtEntry[K, V]{node: child}
+	return node, true
+}
+
+// hamtPair creates the smallest subtrie at the given shift that holds both entries.
+func hamtPair[K comparable, V any](shift uint, a, b hamtEntry[K, V], owner *hamtOwner) *hamtNode[K, V] {
+	if shift > hamtMaxShift {
+		return &hamtNode[K, V]{entries: []hamtEntry[K, V]{a, b}, owner: owner}
+	}
+	i := (a.hash >> shift) & hamtMask
+	j := (b.hash >> shift) & hamtMask
+	if i == j {
+		child := hamtPair(shift+hamtBits, a, b, owner)
+		return &hamtNode[K, V]{bitmap: 1 << i, entries: []hamtEntry[K, V]{{node: child}}, owner: owner}
+	}
+	if i > j {
+		a, b = b, a
+	}
+	return &hamtNode[K, V]{bitmap: 1<<i | 1<<j, entries: []hamtEntry[K, V]{a, b}, owner: owner}
+}
+
+// hamtDelete removes the key from the trie rooted at node, returning the new root (nil when empty)
+// and whether the key was present. Nodes not owned by owner are copied rather than modified.
+func hamtDelete[K comparable, V any](node *hamtNode[K, V], shift uint, hash uint64, key K, owner *hamtOwner) (*hamtNode[K, V], bool) {
+	if node == nil {
+		return nil, false
+	}
+	if shift > hamtMaxShift {
+		for i, e := range node.entries {
+			if e.key == key {
+				if len(node.entries) == 1 {
+					return nil, true
+				}
+				node = node.editable(owner)
+				node.removeEntry(i)
+				return node, true
+			}
+		}
+		return node, false
+	}
+	bit, i := hamtIndex(node.bitmap, hash, shift)
+	if node.bitmap&bit == 0 {
+		return node, false
+	}
+	current := node.entries[i]
+	if current.node == nil {
+		if current.hash != hash || current.key != key {
+			return node, false
+		}
+		if len(node.entries) == 1 {
+			return nil, true
+		}
+		node = node.editable(owner)
+		node.removeEntry(i)
+		node.bitmap &^= bit
+		return node, true
+	}
+	child, ok := hamtDelete(current.node, shift+hamtBits, hash, key, owner)
+	if !ok {
+		return node, false
+	}
+	switch {
+	case child == nil && len(node.entries) == 1:
+		return nil, true
+	case child == nil:
+		node = node.editable(owner)
+		node.removeEntry(i)
+		node.bitmap &^= bit
+	case len(child.entries) == 1 && child.entries[0].node == nil:
+		// Pull a lone key-value pair up into its parent to keep the trie shallow.
+		node = node.editable(owner)
+		node.entries[i] = child.entries[0]
+	default:
+		node = node.editable(owner)
+		node.entries[i].node = child
+	}
+	return node, true
+}
+
+// hamtEach calls fn for each key-value pair in the trie rooted at node until fn returns false.
+func hamtEach[K comparable, V any](node *hamtNode[K, V], fn func(key K, value V) bool) bool {
+	if node == nil {
+		return true
+	}
+	for _, entry := range node.entries {
+		if entry.node != nil {
+			if !hamtEach(entry.node, fn) {
+				return false
+			}
+		} else if !fn(entry.key, entry.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// ImmutableMap is a persistent map backed by a hash array mapped trie.
+// Operations that modify an ImmutableMap return a new version in O(log n), sharing unchanged structure
+// with the previous version, which remains valid and unchanged. An ImmutableMap is safe for concurrent use.
+type ImmutableMap[K comparable, V any] struct {
+	hasher Hasher[K]
+	length int
+	root   *hamtNode[K, V]
+}
+
+// NewImmutableMap creates an empty ImmutableMap that hashes keys using the default Hasher.
+// The default Hasher handles built-in scalar key types directly and falls back to hashing the fields
+// and elements of other keys using reflection; provide a dedicated Hasher via NewImmutableMapHasher for hot paths.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]()
+//	next := immutableMap.Set("apple", 5) // immutableMap is still empty
+func NewImmutableMap[K comparable, V any]() *ImmutableMap[K, V] {
+	return NewImmutableMapHasher[K, V](defaultHasher[K]{})
+}
+
+// NewImmutableMapHasher creates an empty ImmutableMap that hashes keys using the provided Hasher.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMapHasher[int, string](gomap.HasherFunc[int](func(key int) uint64 {
+//		return uint64(key)
+//	}))
+func NewImmutableMapHasher[K comparable, V any](hasher Hasher[K]) *ImmutableMap[K, V] {
+	return &ImmutableMap[K, V]{hasher: hasher}
+}
+
+// hash returns the hash of the key, falling back to the default Hasher for a zero ImmutableMap.
+func (immutableMap *ImmutableMap[K, V]) hash(key K) uint64 {
+	if immutableMap.hasher == nil {
+		return defaultHasher[K]{}.Hash(key)
+	}
+	return immutableMap.hasher.Hash(key)
+}
+
+// Builder returns an ImmutableMapBuilder initialized with the contents of the map.
+// The map itself is not affected by changes made through the builder.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5)
+//	builder := immutableMap.Builder()
+//	builder.Set("banana", 3).Delete("apple")
+//	next := builder.Build() // {"banana": 3}, immutableMap is still {"apple": 5}
+func (immutableMap *ImmutableMap[K, V]) Builder() *ImmutableMapBuilder[K, V] {
+	return &ImmutableMapBuilder[K, V]{
+		hasher: immutableMap.hasher,
+		length: immutableMap.length,
+		owner:  &hamtOwner{},
+		root:   immutableMap.root}
+}
+
+// Delete returns a new ImmutableMap without the provided key. If the key is not present, the receiver is returned.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5).Set("banana", 3)
+//	next := immutableMap.Delete("apple") // {"banana": 3}, immutableMap still holds "apple"
+func (immutableMap *ImmutableMap[K, V]) Delete(key K) *ImmutableMap[K, V] {
+	root, ok := hamtDelete(immutableMap.root, 0, immutableMap.hash(key), key, nil)
+	if !ok {
+		return immutableMap
+	}
+	return &ImmutableMap[K, V]{hasher: immutableMap.hasher, length: immutableMap.length - 1, root: root}
+}
+
+// Each executes the provided function for each key-value pair in the map.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5)
+//	immutableMap.Each(func(key string, value int) {
+//		fmt.Println(key, value) // apple 5
+//	})
+func (immutableMap *ImmutableMap[K, V]) Each(fn func(key K, value V)) *ImmutableMap[K, V] {
+	return immutableMap.EachBreak(func(key K, value V) bool {
+		fn(key, value)
+		return true
+	})
+}
+
+// EachBreak executes the provided function for each key-value pair in the map and stops when the function returns false.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5).Set("banana", 3)
+//	immutableMap.EachBreak(func(key string, value int) bool {
+//		return key != "apple" // Stops at "apple"
+//	})
+func (immutableMap *ImmutableMap[K, V]) EachBreak(fn func(key K, value V) bool) *ImmutableMap[K, V] {
+	hamtEach(immutableMap.root, fn)
+	return immutableMap
+}
+
+// Fetch retrieves the value associated with the given key, or the zero value for the value type if the key is not present.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5)
+//	value := immutableMap.Fetch("apple") // 5
+func (immutableMap *ImmutableMap[K, V]) Fetch(key K) V {
+	value, _ := immutableMap.Get(key)
+	return value
+}
+
+// Get retrieves the value associated with the provided key and a boolean indicating whether the key exists.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5)
+//	value, ok := immutableMap.Get("apple") // 5, true
+//	value, ok = immutableMap.Get("orange") // 0, false
+func (immutableMap *ImmutableMap[K, V]) Get(key K) (V, bool) {
+	return hamtGet(immutableMap.root, immutableMap.hash(key), key)
+}
+
+// Has checks if the provided key exists in the map.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5)
+//	ok := immutableMap.Has("apple") // true
+func (immutableMap *ImmutableMap[K, V]) Has(key K) bool {
+	_, ok := immutableMap.Get(key)
+	return ok
+}
+
+// IsEmpty checks if the map contains no key-value pairs.
+func (immutableMap *ImmutableMap[K, V]) IsEmpty() bool {
+	return immutableMap.Length() == 0
+}
+
+// IsPopulated checks if the map contains at least one key-value pair.
+func (immutableMap *ImmutableMap[K, V]) IsPopulated() bool {
+	return !immutableMap.IsEmpty()
+}
+
+// Keys returns a slice containing all the keys present in the map.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5)
+//	keys := immutableMap.Keys() // {"apple"}
+func (immutableMap *ImmutableMap[K, V]) Keys() *slice.Slice[K] {
+	keys := make(slice.Slice[K], 0, immutableMap.Length())
+	immutableMap.Each(func(key K, value V) {
+		keys.Append(key)
+	})
+	return &keys
+}
+
+// Length returns the number of key-value pairs in the map.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5)
+//	length := immutableMap.Length() // 1
+func (immutableMap *ImmutableMap[K, V]) Length() int {
+	return immutableMap.length
+}
+
+// Map returns a new mutable Map containing the key-value pairs of the ImmutableMap.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5)
+//	newMap := immutableMap.Map() // &map[apple:5]
+func (immutableMap *ImmutableMap[K, V]) Map() *Map[K, V] {
+	newMap := make(Map[K, V], immutableMap.Length())
+	immutableMap.Each(func(key K, value V) {
+		newMap.Add(key, value)
+	})
+	return &newMap
+}
+
+// Merge returns a new ImmutableMap containing the key-value pairs of both maps.
+// If a key exists in both maps, the value from the other map is used.
+//
+//	// Create two new ImmutableMap instances.
+//	immutableMap1 := gomap.NewImmutableMap[string, int]().Set("apple", 5)
+//	immutableMap2 := gomap.NewImmutableMap[string, int]().Set("apple", 7).Set("banana", 3)
+//	merged := immutableMap1.Merge(immutableMap2) // {"apple": 7, "banana": 3}
+func (immutableMap *ImmutableMap[K, V]) Merge(other *ImmutableMap[K, V]) *ImmutableMap[K, V] {
+	if other.IsEmpty() {
+		return immutableMap
+	}
+	builder := immutableMap.Builder()
+	other.Each(func(key K, value V) {
+		builder.Set(key, value)
+	})
+	return builder.Build()
+}
+
+// Set returns a new ImmutableMap with the key associated with the value.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]()
+//	next := immutableMap.Set("apple", 5) // {"apple": 5}, immutableMap is still empty
+func (immutableMap *ImmutableMap[K, V]) Set(key K, value V) *ImmutableMap[K, V] {
+	entry := hamtEntry[K, V]{hash: immutableMap.hash(key), key: key, value: value}
+	root, ok := hamtSet(immutableMap.root, 0, entry, nil)
+	length := immutableMap.length
+	if ok {
+		length++
+	}
+	return &ImmutableMap[K, V]{hasher: immutableMap.hasher, length: length, root: root}
+}
+
+// Values returns a slice containing all the values present in the map.
+//
+//	// Create a new ImmutableMap instance.
+//	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5)
+//	values := immutableMap.Values() // {5}
+func (immutableMap *ImmutableMap[K, V]) Values() *slice.Slice[V] {
+	values := make(slice.Slice[V], 0, immutableMap.Length())
+	immutableMap.Each(func(key K, value V) {
+		values.Append(value)
+	})
+	return &values
+}
+
+// ImmutableMapBuilder efficiently constructs an ImmutableMap by modifying nodes it owns in place
+// instead of copying them for every change. An ImmutableMapBuilder is not safe for concurrent use.
+type ImmutableMapBuilder[K comparable, V any] struct {
+	hasher Hasher[K]
+	length int
+	owner  *hamtOwner
+	root   *hamtNode[K, V]
+}
+
+// NewImmutableMapBuilder creates an empty ImmutableMapBuilder that hashes keys using the default Hasher.
+//
+//	// Create a new ImmutableMapBuilder instance.
+//	builder := gomap.NewImmutableMapBuilder[string, int]()
+//	for i, key := range []string{"apple", "banana", "cherry"} {
+//		builder.Set(key, i)
+//	}
+//	immutableMap := builder.Build()
+func NewImmutableMapBuilder[K comparable, V any]() *ImmutableMapBuilder[K, V] {
+	return NewImmutableMap[K, V]().Builder()
+}
+
+// Build returns an ImmutableMap holding the current contents of the builder.
+// The builder remains usable; later changes do not affect maps that were already built.
+func (builder *ImmutableMapBuilder[K, V]) Build() *ImmutableMap[K, V] {
+	immutableMap := &ImmutableMap[K, V]{hasher: builder.hasher, length: builder.length, root: builder.root}
+	builder.owner = &hamtOwner{}
+	return immutableMap
+}
+
+// Delete removes the key from the builder.
+func (builder *ImmutableMapBuilder[K, V]) Delete(key K) *ImmutableMapBuilder[K, V] {
+	root, ok := hamtDelete(builder.root, 0, builder.hash(key), key, builder.owner)
+	if ok {
+		builder.length--
+	}
+	builder.root = root
+	return builder
+}
+
+// Get retrieves the value associated with the provided key and a boolean indicating whether the key exists.
+func (builder *ImmutableMapBuilder[K, V]) Get(key K) (V, bool) {
+	return hamtGet(builder.root, builder.hash(key), key)
+}
+
+// Has checks if the provided key exists in the builder.
+func (builder *ImmutableMapBuilder[K, V]) Has(key K) bool {
+	_, ok := builder.Get(key)
+	return ok
+}
+
+// Length returns the number of key-value pairs in the builder.
+func (builder *ImmutableMapBuilder[K, V]) Length() int {
+	return builder.length
+}
+
+// Set associates the key with the value in the builder.
+func (builder *ImmutableMapBuilder[K, V]) Set(key K, value V) *ImmutableMapBuilder[K, V] {
+	entry := hamtEntry[K, V]{hash: builder.hash(key), key: key, value: value}
+	root, ok := hamtSet(builder.root, 0, entry, builder.owner)
+	if ok {
+		builder.length++
+	}
+	builder.root = root
+	return builder
+}
+
+// hash returns the hash of the key, falling back to the default Hasher when none is configured.
+func (builder *ImmutableMapBuilder[K, V]) hash(key K) uint64 {
+	if builder.hasher == nil {
+		return defaultHasher[K]{}.Hash(key)
+	}
+	return builder.hasher.Hash(key)
+}
@@ -0,0 +1,207 @@
+package gomap_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// collidingHasher hashes every key into one of four buckets to force collision nodes.
+var collidingHasher = gomap.HasherFunc[int](func(key int) uint64 {
+	return uint64(key % 4)
+})
+
+// TestImmutableMapSet tests ImmutableMap.Set.
+func TestImmutableMapSet(t *testing.T) {
+	empty := gomap.NewImmutableMap[string, int]()
+	immutableMap := empty.Set("apple", 5).Set("banana", 3)
+
+	// Verify the previous version is unchanged.
+	if empty.Length() != 0 || empty.Has("apple") {
+		t.Errorf("Expected the empty map to remain empty, but got length %d", empty.Length())
+	}
+
+	// Verify the values using Get method.
+	if value, ok := immutableMap.Get("apple"); !ok || value != 5 {
+		t.Errorf("Expected key 'apple' to have value 5, but got %v", value)
+	}
+	if value, ok := immutableMap.Get("banana"); !ok || value != 3 {
+		t.Errorf("Expected key 'banana' to have value 3, but got %v", value)
+	}
+
+	// Replace an existing key.
+	replaced := immutableMap.Set("banana", 10)
+	if replaced.Length() != 2 {
+		t.Errorf("Expected length 2 after replacing 'banana', but got %d", replaced.Length())
+	}
+	if value := replaced.Fetch("banana"); value != 10 {
+		t.Errorf("Expected key 'banana' to have value 10, but got %v", value)
+	}
+	if value := immutableMap.Fetch("banana"); value != 3 {
+		t.Errorf("Expected previous version to keep value 3 for 'banana', but got %v", value)
+	}
+}
+
+// TestImmutableMapDelete tests ImmutableMap.Delete.
+func TestImmutableMapDelete(t *testing.T) {
+	immutableMap := gomap.NewImmutableMap[string, int]().Set("apple", 5).Set("banana", 3)
+	deleted := immutableMap.Delete("apple")
+
+	if deleted.Has("apple") {
+		t.Errorf("Expected key 'apple' to be deleted")
+	}
+	if deleted.Length() != 1 {
+		t.Errorf("Expected length 1 after delete, but got %d", deleted.Length())
+	}
+	if !immutableMap.Has("apple") || immutableMap.Length() != 2 {
+		t.Errorf("Expected previous version to still hold 'apple'")
+	}
+
+	// Deleting a missing key returns the same map.
+	if same := deleted.Delete("orange"); same != deleted {
+		t.Errorf("Expected deleting a missing key to return the receiver")
+	}
+}
+
+// TestImmutableMapCollisions tests ImmutableMap with keys that share hashes.
+func TestImmutableMapCollisions(t *testing.T) {
+	immutableMap := gomap.NewImmutableMapHasher[int, int](collidingHasher)
+	for i := 0; i < 64; i++ {
+		immutableMap = immutableMap.Set(i, i*i)
+	}
+	if immutableMap.Length() != 64 {
+		t.Fatalf("Expected length 64, but got %d", immutableMap.Length())
+	}
+	for i := 0; i < 64; i++ {
+		if value, ok := immutableMap.Get(i); !ok || value != i*i {
+			t.Fatalf("Expected key %d to have value %d, but got %v", i, i*i, value)
+		}
+	}
+	for i := 0; i < 64; i += 2 {
+		immutableMap = immutableMap.Delete(i)
+	}
+	for i := 0; i < 64; i++ {
+		if ok := immutableMap.Has(i); ok != (i%2 == 1) {
+			t.Fatalf("Expected Has(%d) to be %t, but got %t", i, i%2 == 1, ok)
+		}
+	}
+}
+
+// TestImmutableMapMerge tests ImmutableMap.Merge.
+func TestImmutableMapMerge(t *testing.T) {
+	immutableMap1 := gomap.NewImmutableMap[string, int]().Set("apple", 5).Set("orange", 10)
+	immutableMap2 := gomap.NewImmutableMap[string, int]().Set("apple", 7).Set("banana", 3)
+	merged := immutableMap1.Merge(immutableMap2)
+
+	expected := &gomap.Map[string, int]{"apple": 7, "orange": 10, "banana": 3}
+	if !merged.Map().Equal(expected) {
+		t.Errorf("Expected merged map to be %v, but got %v", expected, merged.Map())
+	}
+	if immutableMap1.Fetch("apple") != 5 || immutableMap1.Has("banana") {
+		t.Errorf("Expected merge to leave the receiver unchanged")
+	}
+}
+
+// TestImmutableMapEqualKeys tests that keys equal according to == are found regardless of their representation.
+func TestImmutableMapEqualKeys(t *testing.T) {
+	type point struct {
+		X, Y float64
+	}
+	type tagged struct {
+		Name  string
+		Value any
+	}
+	negativeZero := math.Copysign(0, -1)
+	points := gomap.NewImmutableMap[point, int]().Set(point{X: negativeZero, Y: 1}, 1)
+	if value, ok := points.Get(point{X: 0, Y: 1}); !ok || value != 1 {
+		t.Errorf("Expected (1, true) for +0, but got (%d, %t)", value, ok)
+	}
+	tags := gomap.NewImmutableMap[tagged, int]().Set(tagged{"a", negativeZero}, 1).Set(tagged{"b", point{X: negativeZero}}, 2)
+	if value, ok := tags.Get(tagged{"a", 0.0}); !ok || value != 1 {
+		t.Errorf("Expected (1, true) for an interface holding +0, but got (%d, %t)", value, ok)
+	}
+	if value, ok := tags.Get(tagged{"b", point{}}); !ok || value != 2 {
+		t.Errorf("Expected (2, true) for an interface holding a struct with +0, but got (%d, %t)", value, ok)
+	}
+	if tags.Has(tagged{"a", int64(0)}) {
+		t.Errorf("Expected an interface holding a different type not to be found")
+	}
+	keys := gomap.NewImmutableMap[any, int]()
+	for i := 0; i < 100; i++ {
+		keys = keys.Set(point{X: float64(i)}, i)
+	}
+	keys = keys.Set(point{X: negativeZero, Y: negativeZero}, -1)
+	if value, ok := keys.Get(point{}); !ok || value != -1 {
+		t.Errorf("Expected (-1, true), but got (%d, %t)", value, ok)
+	}
+}
+
+// TestImmutableMapBuilder tests ImmutableMapBuilder.
+func TestImmutableMapBuilder(t *testing.T) {
+	builder := gomap.NewImmutableMapBuilder[int, int]()
+	for i := 0; i < 100; i++ {
+		builder.Set(i, i)
+	}
+	first := builder.Build()
+
+	// Changes after Build must not leak into maps that were already built.
+	builder.Delete(0).Set(1, -1).Set(100, 100)
+	second := builder.Build()
+
+	if first.Length() != 100 || first.Fetch(1) != 1 || !first.Has(0) || first.Has(100) {
+		t.Errorf("Expected first build to be unaffected by later builder changes")
+	}
+	if second.Length() != 100 || second.Fetch(1) != -1 || second.Has(0) || !second.Has(100) {
+		t.Errorf("Expected second build to contain builder changes")
+	}
+}
+
+// TestImmutableMapRandom tests ImmutableMap against Map using random operations.
+func TestImmutableMapRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, immutableMap := range []*gomap.ImmutableMap[int, int]{
+		gomap.NewImmutableMap[int, int](),
+		gomap.NewImmutableMapHasher[int, int](collidingHasher)} {
+		reference := &gomap.Map[int, int]{}
+		versions := []*gomap.ImmutableMap[int, int]{}
+		snapshots := []*gomap.Map[int, int]{}
+		for i := 0; i < 2000; i++ {
+			key := random.Intn(256)
+			if random.Intn(3) == 0 {
+				immutableMap = immutableMap.Delete(key)
+				reference.Delete(key)
+			} else {
+				immutableMap = immutableMap.Set(key, i)
+				reference.Add(key, i)
+			}
+			if i%100 == 0 {
+				versions = append(versions, immutableMap)
+				snapshots = append(snapshots, reference.Filter(func(key int, value int) bool { return true }))
+			}
+		}
+		if !immutableMap.Map().Equal(reference) {
+			t.Fatalf("Expected immutable map to match reference map")
+		}
+		for i, version := range versions {
+			if !version.Map().Equal(snapshots[i]) {
+				t.Fatalf("Expected version %d to be unchanged by later operations", i)
+			}
+		}
+	}
+}
+
+// TestImmutable tests Map.Immutable.
+func TestImmutable(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3}
+	immutableMap := newMap.Immutable()
+	newMap.Add("cherry", 8)
+
+	if immutableMap.Length() != 2 || immutableMap.Has("cherry") {
+		t.Errorf("Expected immutable map to be unaffected by later changes")
+	}
+	if !immutableMap.Keys().Contains("apple") || !immutableMap.Values().Contains(3) {
+		t.Errorf("Expected immutable map to contain the original pairs")
+	}
+}
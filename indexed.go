@@ -0,0 +1,271 @@
+package gomap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lindsaygelle/slice"
+)
+
+var (
+	// ErrIndexConflict is returned when a value would share a unique index key with a value stored under another key.
+	ErrIndexConflict = errors.New("gomap: unique index conflict")
+	// ErrIndexExists is returned when adding an index with a name that is already in use.
+	ErrIndexExists = errors.New("gomap: index already exists")
+)
+
+// IndexFunc returns the index keys a value is stored under in an index. A value may have any number of index keys.
+type IndexFunc[V any] func(value V) []string
+
+// index maps index keys to the set of map keys whose values produced them.
+type index[K comparable, V any] struct {
+	entries map[string]map[K]struct{}
+	fn      IndexFunc[V]
+	unique  bool
+}
+
+// indexKeys returns the distinct index keys of the value.
+func (index *index[K, V]) indexKeys(value V) []string {
+	keys := index.fn(value)
+	seen := make(map[string]struct{}, len(keys))
+	distinct := keys[:0:0]
+	for _, key := range keys {
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			distinct = append(distinct, key)
+		}
+	}
+	return distinct
+}
+
+// insert adds the key under each of the index keys.
+func (index *index[K, V]) insert(key K, indexKeys []string) {
+	for _, indexKey := range indexKeys {
+		keys, ok := index.entries[indexKey]
+		if !ok {
+			keys = make(map[K]struct{})
+			index.entries[indexKey] = keys
+		}
+		keys[key] = struct{}{}
+	}
+}
+
+// remove deletes the key from each of the index keys.
+func (index *index[K, V]) remove(key K, indexKeys []string) {
+	for _, indexKey := range indexKeys {
+		delete(index.entries[indexKey], key)
+		if len(index.entries[indexKey]) == 0 {
+			delete(index.entries, indexKey)
+		}
+	}
+}
+
+// conflict returns the key, other than the provided key, already stored under one of the index keys of a unique index.
+func (index *index[K, V]) conflict(key K, indexKeys []string) (string, K, bool) {
+	if index.unique {
+		for _, indexKey := range indexKeys {
+			for owner := range index.entries[indexKey] {
+				if owner != key {
+					return indexKey, owner, true
+				}
+			}
+		}
+	}
+	var owner K
+	return "", owner, false
+}
+
+// IndexedMap is a map that maintains named secondary indexes over its values, so that values can be looked up
+// by an attribute without scanning the map. Indexes are updated on every Add, Delete and Replace.
+// The index keys of a value are computed when it is added, so changes made to a stored value through
+// a pointer, slice or map it holds are not reflected in the indexes until the value is added again.
+type IndexedMap[K comparable, V any] struct {
+	gomap     Map[K, V]
+	indexes   map[string]*index[K, V]
+	indexKeys map[K]map[string][]string
+}
+
+// NewIndexedMap creates an empty IndexedMap without indexes.
+//
+//	// Create a new IndexedMap instance.
+//	users := gomap.NewIndexedMap[int, User]()
+//	users.AddUniqueIndex("email", func(user User) []string { return []string{user.Email} })
+//	users.Add(1, User{Email: "ada@example.com"})
+//	matches := users.Lookup("email", "ada@example.com") // &map[1:{ada@example.com}]
+func NewIndexedMap[K comparable, V any]() *IndexedMap[K, V] {
+	return &IndexedMap[K, V]{
+		gomap:     make(Map[K, V]),
+		indexes:   make(map[string]*index[K, V]),
+		indexKeys: make(map[K]map[string][]string),
+	}
+}
+
+// Add inserts or updates the key-value pair and updates every index.
+// It returns an error wrapping ErrIndexConflict, and leaves the map unchanged, if the value would share
+// a unique index key with a value stored under another key.
+//
+//	// Create a new IndexedMap instance.
+//	users := gomap.NewIndexedMap[int, string]()
+//	users.AddUniqueIndex("name", func(name string) []string { return []string{name} })
+//	err := users.Add(1, "ada")  // nil
+//	err = users.Add(2, "ada")   // gomap: unique index conflict: "ada" in index "name" is associated with 1
+func (indexedMap *IndexedMap[K, V]) Add(key K, value V) error {
+	indexKeys := make(map[string][]string, len(indexedMap.indexes))
+	for name, index := range indexedMap.indexes {
+		indexKeys[name] = index.indexKeys(value)
+		if indexKey, owner, ok := index.conflict(key, indexKeys[name]); ok {
+			return fmt.Errorf("%w: %q in index %q is associated with %v", ErrIndexConflict, indexKey, name, owner)
+		}
+	}
+	indexedMap.removeIndexKeys(key)
+	for name, index := range indexedMap.indexes {
+		index.insert(key, indexKeys[name])
+	}
+	indexedMap.indexKeys[key] = indexKeys
+	indexedMap.gomap.Add(key, value)
+	return nil
+}
+
+// AddIndex adds a named index built from the index keys returned by fn for each value.
+// It returns an error wrapping ErrIndexExists if an index with the name already exists.
+//
+//	// Create a new IndexedMap instance.
+//	users := gomap.NewIndexedMap[int, User]()
+//	err := users.AddIndex("role", func(user User) []string { return user.Roles })
+func (indexedMap *IndexedMap[K, V]) AddIndex(name string, fn IndexFunc[V]) error {
+	return indexedMap.addIndex(name, fn, false)
+}
+
+// AddUniqueIndex adds a named index in which each index key may belong to at most one map key.
+// It returns an error wrapping ErrIndexExists if an index with the name already exists,
+// or ErrIndexConflict if the values already in the map violate the constraint.
+//
+//	// Create a new IndexedMap instance.
+//	users := gomap.NewIndexedMap[int, User]()
+//	err := users.AddUniqueIndex("email", func(user User) []string { return []string{user.Email} })
+func (indexedMap *IndexedMap[K, V]) AddUniqueIndex(name string, fn IndexFunc[V]) error {
+	return indexedMap.addIndex(name, fn, true)
+}
+
+// addIndex builds the index from the values in the map and adds it if they do not conflict.
+func (indexedMap *IndexedMap[K, V]) addIndex(name string, fn IndexFunc[V], unique bool) error {
+	if _, ok := indexedMap.indexes[name]; ok {
+		return fmt.Errorf("%w: %q", ErrIndexExists, name)
+	}
+	index := &index[K, V]{entries: make(map[string]map[K]struct{}), fn: fn, unique: unique}
+	indexKeys := make(map[K][]string, indexedMap.gomap.Length())
+	for key, value := range indexedMap.gomap {
+		indexKeys[key] = index.indexKeys(value)
+		if indexKey, owner, ok := index.conflict(key, indexKeys[key]); ok {
+			return fmt.Errorf("%w: %q in index %q is associated with %v and %v", ErrIndexConflict, indexKey, name, owner, key)
+		}
+		index.insert(key, indexKeys[key])
+	}
+	for key, keys := range indexKeys {
+		indexedMap.indexKeys[key][name] = keys
+	}
+	indexedMap.indexes[name] = index
+	return nil
+}
+
+// Delete removes the key-value pair with the provided key from the map and its indexes, and reports whether it was present.
+func (indexedMap *IndexedMap[K, V]) Delete(key K) bool {
+	_, ok := indexedMap.gomap.PopOK(key)
+	if ok {
+		indexedMap.removeIndexKeys(key)
+		delete(indexedMap.indexKeys, key)
+	}
+	return ok
+}
+
+// DeleteIndex removes the named index and reports whether it existed.
+func (indexedMap *IndexedMap[K, V]) DeleteIndex(name string) bool {
+	_, ok := indexedMap.indexes[name]
+	if ok {
+		delete(indexedMap.indexes, name)
+		for _, indexKeys := range indexedMap.indexKeys {
+			delete(indexKeys, name)
+		}
+	}
+	return ok
+}
+
+// Each executes the provided function for each key-value pair in the map.
+func (indexedMap *IndexedMap[K, V]) Each(fn func(key K, value V)) *IndexedMap[K, V] {
+	indexedMap.gomap.Each(fn)
+	return indexedMap
+}
+
+// Fetch retrieves the value associated with the given key, or the zero value for the value type if the key is not present.
+func (indexedMap *IndexedMap[K, V]) Fetch(key K) V {
+	return indexedMap.gomap.Fetch(key)
+}
+
+// Get retrieves the value associated with the provided key and a boolean indicating whether the key exists.
+func (indexedMap *IndexedMap[K, V]) Get(key K) (V, bool) {
+	return indexedMap.gomap.Get(key)
+}
+
+// Has checks if the provided key exists in the map.
+func (indexedMap *IndexedMap[K, V]) Has(key K) bool {
+	return indexedMap.gomap.Has(key)
+}
+
+// HasIndex checks if an index with the provided name exists.
+func (indexedMap *IndexedMap[K, V]) HasIndex(name string) bool {
+	_, ok := indexedMap.indexes[name]
+	return ok
+}
+
+// Keys returns a slice containing all the keys present in the map.
+func (indexedMap *IndexedMap[K, V]) Keys() *slice.Slice[K] {
+	return indexedMap.gomap.Keys()
+}
+
+// Length returns the number of key-value pairs in the map.
+func (indexedMap *IndexedMap[K, V]) Length() int {
+	return indexedMap.gomap.Length()
+}
+
+// Lookup returns a new Map containing the key-value pairs stored under the index key in the named index.
+// The Map is empty if the index key or the index does not exist.
+//
+//	// Create a new IndexedMap instance.
+//	users := gomap.NewIndexedMap[int, User]()
+//	users.AddIndex("role", func(user User) []string { return user.Roles })
+//	users.Add(1, User{Name: "ada", Roles: []string{"admin"}})
+//	admins := users.Lookup("role", "admin") // &map[1:{ada [admin]}]
+func (indexedMap *IndexedMap[K, V]) Lookup(name string, indexKey string) *Map[K, V] {
+	newMap := make(Map[K, V])
+	if index, ok := indexedMap.indexes[name]; ok {
+		for key := range index.entries[indexKey] {
+			newMap.Add(key, indexedMap.gomap.Fetch(key))
+		}
+	}
+	return &newMap
+}
+
+// removeIndexKeys removes the key from the index keys it was stored under when its value was added.
+func (indexedMap *IndexedMap[K, V]) removeIndexKeys(key K) {
+	for name, indexKeys := range indexedMap.indexKeys[key] {
+		indexedMap.indexes[name].remove(key, indexKeys)
+	}
+}
+
+// Replace updates the value of an existing key and its indexes, and reports whether the key was present.
+// Keys that are not present are not added. It returns an error wrapping ErrIndexConflict, and leaves the map unchanged,
+// if the value would share a unique index key with a value stored under another key.
+func (indexedMap *IndexedMap[K, V]) Replace(key K, value V) (bool, error) {
+	if !indexedMap.gomap.Has(key) {
+		return false, nil
+	}
+	if err := indexedMap.Add(key, value); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Values returns a slice containing all the values present in the map.
+func (indexedMap *IndexedMap[K, V]) Values() *slice.Slice[V] {
+	return indexedMap.gomap.Values()
+}
@@ -0,0 +1,230 @@
+package gomap_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// user is a value stored in the IndexedMap tests.
+type user struct {
+	Email string
+	Roles []string
+}
+
+// newUsers returns an IndexedMap of users indexed by email and role.
+func newUsers(t *testing.T) *gomap.IndexedMap[int, user] {
+	users := gomap.NewIndexedMap[int, user]()
+	if err := users.AddUniqueIndex("email", func(value user) []string { return []string{value.Email} }); err != nil {
+		t.Fatalf("Expected AddUniqueIndex to succeed, but got %v", err)
+	}
+	if err := users.AddIndex("role", func(value user) []string { return value.Roles }); err != nil {
+		t.Fatalf("Expected AddIndex to succeed, but got %v", err)
+	}
+	return users
+}
+
+// TestIndexedMapAdd tests IndexedMap.Add.
+func TestIndexedMapAdd(t *testing.T) {
+	users := newUsers(t)
+	users.Add(1, user{"ada@example.com", []string{"admin", "dev"}})
+	users.Add(2, user{"bob@example.com", []string{"dev"}})
+
+	if matches := users.Lookup("role", "dev"); matches.Length() != 2 {
+		t.Errorf("Expected 2 developers, but got %v", matches)
+	}
+	if matches := users.Lookup("email", "ada@example.com"); !matches.Has(1) || matches.Length() != 1 {
+		t.Errorf("Expected user 1, but got %v", matches)
+	}
+
+	// Test case 1: Unique indexes reject conflicting values and leave the map unchanged.
+	err := users.Add(3, user{"ada@example.com", []string{"ops"}})
+	if !errors.Is(err, gomap.ErrIndexConflict) {
+		t.Errorf("Expected ErrIndexConflict, but got %v", err)
+	}
+	if users.Has(3) || users.Lookup("role", "ops").IsPopulated() {
+		t.Errorf("Expected the conflicting value not to be added")
+	}
+
+	// Test case 2: Updating a key replaces its index entries.
+	if err := users.Add(1, user{"ada@example.com", []string{"ops"}}); err != nil {
+		t.Errorf("Expected a key to keep its own unique index key, but got %v", err)
+	}
+	if users.Lookup("role", "admin").IsPopulated() || !users.Lookup("role", "ops").Has(1) {
+		t.Errorf("Expected the roles of user 1 to be reindexed")
+	}
+}
+
+// TestIndexedMapDelete tests IndexedMap.Delete.
+func TestIndexedMapDelete(t *testing.T) {
+	users := newUsers(t)
+	users.Add(1, user{"ada@example.com", []string{"admin"}})
+	if !users.Delete(1) || users.Delete(1) {
+		t.Errorf("Expected Delete to report whether the key was present")
+	}
+	if users.Lookup("email", "ada@example.com").IsPopulated() || users.Lookup("role", "admin").IsPopulated() {
+		t.Errorf("Expected the index entries to be removed")
+	}
+	if err := users.Add(2, user{"ada@example.com", nil}); err != nil {
+		t.Errorf("Expected the email to be available again, but got %v", err)
+	}
+}
+
+// TestIndexedMapReplace tests IndexedMap.Replace.
+func TestIndexedMapReplace(t *testing.T) {
+	users := newUsers(t)
+	users.Add(1, user{"ada@example.com", nil})
+	users.Add(2, user{"bob@example.com", nil})
+
+	if ok, err := users.Replace(3, user{"cy@example.com", nil}); ok || err != nil || users.Has(3) {
+		t.Errorf("Expected Replace not to add missing keys, but got %t, %v", ok, err)
+	}
+	if ok, err := users.Replace(2, user{"ada@example.com", nil}); ok || !errors.Is(err, gomap.ErrIndexConflict) {
+		t.Errorf("Expected ErrIndexConflict, but got %t, %v", ok, err)
+	}
+	if ok, err := users.Replace(2, user{"bo@example.com", nil}); !ok || err != nil {
+		t.Errorf("Expected Replace to succeed, but got %t, %v", ok, err)
+	}
+	if users.Lookup("email", "bob@example.com").IsPopulated() || !users.Lookup("email", "bo@example.com").Has(2) {
+		t.Errorf("Expected the email of user 2 to be reindexed")
+	}
+}
+
+// TestIndexedMapAddIndex tests IndexedMap.AddIndex and IndexedMap.AddUniqueIndex on a populated map.
+func TestIndexedMapAddIndex(t *testing.T) {
+	users := gomap.NewIndexedMap[int, user]()
+	users.Add(1, user{"ada@example.com", []string{"dev"}})
+	users.Add(2, user{"bob@example.com", []string{"dev"}})
+
+	if err := users.AddIndex("role", func(value user) []string { return value.Roles }); err != nil {
+		t.Fatalf("Expected AddIndex to succeed, but got %v", err)
+	}
+	if matches := users.Lookup("role", "dev"); matches.Length() != 2 {
+		t.Errorf("Expected existing values to be indexed, but got %v", matches)
+	}
+	if err := users.AddIndex("role", func(value user) []string { return nil }); !errors.Is(err, gomap.ErrIndexExists) {
+		t.Errorf("Expected ErrIndexExists, but got %v", err)
+	}
+	if err := users.AddUniqueIndex("unique_role", func(value user) []string { return value.Roles }); !errors.Is(err, gomap.ErrIndexConflict) {
+		t.Errorf("Expected ErrIndexConflict, but got %v", err)
+	}
+	if users.HasIndex("unique_role") {
+		t.Errorf("Expected the conflicting index not to be added")
+	}
+	if !users.DeleteIndex("role") || users.HasIndex("role") || users.Lookup("role", "dev").IsPopulated() {
+		t.Errorf("Expected the index to be deleted")
+	}
+}
+
+// TestIndexedMapConsistency tests that indexes match brute-force scans after random operations.
+func TestIndexedMapConsistency(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	tags := func(value int) []string {
+		return []string{fmt.Sprint(value % 3), fmt.Sprint(value % 5), fmt.Sprint(value % 3)}
+	}
+	unique := func(value int) []string {
+		return []string{fmt.Sprint(value % 20)}
+	}
+	indexedMap := gomap.NewIndexedMap[int, int]()
+	indexedMap.AddIndex("tags", tags)
+	indexedMap.AddUniqueIndex("unique", unique)
+	contains := func(indexKeys []string, indexKey string) bool {
+		for _, key := range indexKeys {
+			if key == indexKey {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i := 0; i < 2000; i++ {
+		key, value := random.Intn(30), random.Intn(100)
+		switch random.Intn(3) {
+		case 0, 1:
+			conflict := false
+			indexedMap.Each(func(k int, v int) {
+				conflict = conflict || k != key && v%20 == value%20
+			})
+			err := indexedMap.Add(key, value)
+			if conflict != (err != nil) {
+				t.Fatalf("Add(%d, %d): expected conflict %t, but got %v", key, value, conflict, err)
+			}
+		case 2:
+			indexedMap.Delete(key)
+		}
+
+		for name, fn := range map[string]gomap.IndexFunc[int]{"tags": tags, "unique": unique} {
+			for indexKey := 0; indexKey < 20; indexKey++ {
+				expected := &gomap.Map[int, int]{}
+				indexedMap.Each(func(k int, v int) {
+					if contains(fn(v), fmt.Sprint(indexKey)) {
+						expected.Add(k, v)
+					}
+				})
+				if got := indexedMap.Lookup(name, fmt.Sprint(indexKey)); !got.Equal(expected) {
+					t.Fatalf("Lookup(%q, %d): expected %v, but got %v", name, indexKey, expected, got)
+				}
+			}
+		}
+	}
+}
+
+// TestIndexedMapMutatedValues tests that indexes stay consistent when pointer values are changed between calls to Add.
+func TestIndexedMapMutatedValues(t *testing.T) {
+	type account struct {
+		Email string
+		Team  int
+	}
+	random := rand.New(rand.NewSource(1))
+	indexedMap := gomap.NewIndexedMap[int, *account]()
+	indexedMap.AddUniqueIndex("email", func(value *account) []string { return []string{value.Email} })
+	indexedMap.AddIndex("team", func(value *account) []string { return []string{fmt.Sprint(value.Team)} })
+	accounts := map[int]*account{}
+	for i := 0; i < 2000; i++ {
+		key := random.Intn(10)
+		value, ok := accounts[key]
+		switch {
+		case !ok || random.Intn(4) == 0:
+			value = &account{}
+		case random.Intn(3) == 0:
+			indexedMap.Delete(key)
+			delete(accounts, key)
+			continue
+		}
+		// Change the value in place, possibly while it is stored in the map, before adding it again.
+		previous := *value
+		value.Email, value.Team = fmt.Sprint(random.Intn(30)), random.Intn(3)
+		conflict := false
+		for k, v := range accounts {
+			conflict = conflict || k != key && v.Email == value.Email
+		}
+		if err := indexedMap.Add(key, value); conflict != (err != nil) {
+			t.Fatalf("Add(%d, %+v): expected conflict %t, but got %v", key, *value, conflict, err)
+		}
+		if conflict {
+			*value = previous // the rejected value may be the stored pointer
+			continue
+		}
+		accounts[key] = value
+		for indexKey := 0; indexKey < 30; indexKey++ {
+			expectedEmail, expectedTeam := &gomap.Map[int, *account]{}, &gomap.Map[int, *account]{}
+			for k, v := range accounts {
+				if v.Email == fmt.Sprint(indexKey) {
+					expectedEmail.Add(k, v)
+				}
+				if v.Team == indexKey {
+					expectedTeam.Add(k, v)
+				}
+			}
+			if got := indexedMap.Lookup("email", fmt.Sprint(indexKey)); !got.Equal(expectedEmail) {
+				t.Fatalf("Lookup(email, %d): expected %v, but got %v", indexKey, expectedEmail, got)
+			}
+			if got := indexedMap.Lookup("team", fmt.Sprint(indexKey)); !got.Equal(expectedTeam) {
+				t.Fatalf("Lookup(team, %d): expected %v, but got %v", indexKey, expectedTeam, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package gomap
+
+import (
+	"github.com/lindsaygelle/slice"
+)
+
+// lwwEntry is the latest value or deletion of a key in an LWWMap and the time it was written.
+type lwwEntry[V any] struct {
+	deleted   bool
+	timestamp HLC
+	value     V
+}
+
+// LWWMap is a last-writer-wins map, a conflict-free replicated data type (CRDT). Each replica records the hybrid
+// logical clock timestamp of every addition and deletion, and merging keeps the write with the latest timestamp
+// for each key. Merge is commutative, associative and idempotent, so replicas that have merged the same updates
+// hold the same key-value pairs regardless of the order in which the merges happened.
+//
+// Deleted keys are kept as tombstones so that deletions are replicated.
+type LWWMap[K comparable, V any] struct {
+	clock   *Clock
+	entries map[K]lwwEntry[V]
+}
+
+// NewLWWMap creates an empty LWWMap for a replica that timestamps its writes using the clock.
+// Each replica must use a Clock with a unique node name.
+//
+//	// Create two replicas.
+//	a := gomap.NewLWWMap[string, int](gomap.NewClock("a"))
+//	b := gomap.NewLWWMap[string, int](gomap.NewClock("b"))
+//	a.Add("apple", 5)
+//	b.Add("apple", 3) // written later, so it wins
+//	a.Merge(b)
+//	value, _ := a.Get("apple") // 3
+func NewLWWMap[K comparable, V any](clock *Clock) *LWWMap[K, V] {
+	return &LWWMap[K, V]{clock: clock, entries: make(map[K]lwwEntry[V])}
+}
+
+// Add sets the value of the key.
+func (lwwMap *LWWMap[K, V]) Add(key K, value V) *LWWMap[K, V] {
+	lwwMap.entries[key] = lwwEntry[V]{timestamp: lwwMap.clock.Now(), value: value}
+	return lwwMap
+}
+
+// Clone returns a copy of the replica that shares its clock.
+func (lwwMap *LWWMap[K, V]) Clone() *LWWMap[K, V] {
+	clone := NewLWWMap[K, V](lwwMap.clock)
+	for key, entry := range lwwMap.entries {
+		clone.entries[key] = entry
+	}
+	return clone
+}
+
+// Delete removes the key.
+func (lwwMap *LWWMap[K, V]) Delete(key K) *LWWMap[K, V] {
+	lwwMap.entries[key] = lwwEntry[V]{deleted: true, timestamp: lwwMap.clock.Now()}
+	return lwwMap
+}
+
+// Each executes the provided function for each key-value pair in the map.
+func (lwwMap *LWWMap[K, V]) Each(fn func(key K, value V)) *LWWMap[K, V] {
+	for key, entry := range lwwMap.entries {
+		if !entry.deleted {
+			fn(key, entry.value)
+		}
+	}
+	return lwwMap
+}
+
+// Get retrieves the value associated with the provided key and a boolean indicating whether the key exists.
+func (lwwMap *LWWMap[K, V]) Get(key K) (V, bool) {
+	entry, ok := lwwMap.entries[key]
+	if !ok || entry.deleted {
+		var value V
+		return value, false
+	}
+	return entry.value, true
+}
+
+// Has checks if the provided key exists in the map.
+func (lwwMap *LWWMap[K, V]) Has(key K) bool {
+	_, ok := lwwMap.Get(key)
+	return ok
+}
+
+// Keys returns a slice containing all the keys present in the map.
+func (lwwMap *LWWMap[K, V]) Keys() *slice.Slice[K] {
+	return lwwMap.Map().Keys()
+}
+
+// Length returns the number of key-value pairs in the map.
+func (lwwMap *LWWMap[K, V]) Length() int {
+	length := 0
+	for _, entry := range lwwMap.entries {
+		if !entry.deleted {
+			length++
+		}
+	}
+	return length
+}
+
+// Map returns a new Map containing the key-value pairs of the replica.
+func (lwwMap *LWWMap[K, V]) Map() *Map[K, V] {
+	newMap := make(Map[K, V])
+	lwwMap.Each(func(key K, value V) {
+		newMap.Add(key, value)
+	})
+	return &newMap
+}
+
+// Merge merges the writes of another replica into the map, keeping the latest write of each key.
+func (lwwMap *LWWMap[K, V]) Merge(other *LWWMap[K, V]) *LWWMap[K, V] {
+	for key, entry := range other.entries {
+		lwwMap.clock.Observe(entry.timestamp)
+		if current, ok := lwwMap.entries[key]; !ok || current.timestamp.Before(entry.timestamp) {
+			lwwMap.entries[key] = entry
+		}
+	}
+	return lwwMap
+}
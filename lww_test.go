@@ -0,0 +1,95 @@
+package gomap_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// TestLWWMapAdd tests LWWMap.Add.
+func TestLWWMapAdd(t *testing.T) {
+	lwwMap := gomap.NewLWWMap[string, int](gomap.NewClock("a"))
+	lwwMap.Add("a", 1).Add("a", 2).Add("b", 3)
+	if value, ok := lwwMap.Get("a"); !ok || value != 2 {
+		t.Errorf("Expected (2, true), but got (%v, %v)", value, ok)
+	}
+	if lwwMap.Length() != 2 {
+		t.Errorf("Expected length 2, but got %d", lwwMap.Length())
+	}
+}
+
+// TestLWWMapDelete tests LWWMap.Delete.
+func TestLWWMapDelete(t *testing.T) {
+	lwwMap := gomap.NewLWWMap[string, int](gomap.NewClock("a"))
+	lwwMap.Add("a", 1).Add("b", 2).Delete("a").Delete("c")
+	if lwwMap.Has("a") || lwwMap.Has("c") {
+		t.Errorf("Expected deleted keys to be absent, but got %v", lwwMap.Map())
+	}
+	if !reflect.DeepEqual(*lwwMap.Map(), gomap.Map[string, int]{"b": 2}) {
+		t.Errorf("Expected map[b:2], but got %v", lwwMap.Map())
+	}
+	if keys := []string(*lwwMap.Keys()); !reflect.DeepEqual(keys, []string{"b"}) {
+		t.Errorf("Expected [b], but got %v", keys)
+	}
+}
+
+// TestLWWMapMerge tests LWWMap.Merge.
+func TestLWWMapMerge(t *testing.T) {
+	a := gomap.NewLWWMap[string, int](newFixedClock("a"))
+	b := gomap.NewLWWMap[string, int](newFixedClock("b"))
+	a.Add("x", 1)
+	b.Add("x", 2) // same wall time and counter; node "b" breaks the tie
+	a.Add("y", 1)
+	b.Merge(a).Delete("y") // the deletion observed the addition, so it wins
+	a.Merge(b)
+	b.Merge(a)
+	for _, replica := range []*gomap.LWWMap[string, int]{a, b} {
+		if !reflect.DeepEqual(*replica.Map(), gomap.Map[string, int]{"x": 2}) {
+			t.Errorf("Expected map[x:2], but got %v", replica.Map())
+		}
+	}
+	a.Add("x", 3) // the clock of a observed the write of b
+	b.Merge(a)
+	if value := b.Map().Fetch("x"); value != 3 {
+		t.Errorf("Expected 3, but got %v", value)
+	}
+}
+
+// TestLWWMapConvergence tests that LWWMap replicas converge regardless of operation interleaving and merge order.
+func TestLWWMapConvergence(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		random := rand.New(rand.NewSource(seed))
+		replicas := make([]*gomap.LWWMap[int, int], 3)
+		for i := range replicas {
+			replicas[i] = gomap.NewLWWMap[int, int](newFixedClock(fmt.Sprint(i)))
+		}
+		for i := 0; i < 200; i++ {
+			replica := replicas[random.Intn(len(replicas))]
+			key := random.Intn(10)
+			switch random.Intn(4) {
+			case 0:
+				replica.Delete(key)
+			case 1:
+				replica.Merge(replicas[random.Intn(len(replicas))])
+			default:
+				replica.Add(key, random.Intn(100))
+			}
+		}
+		var expected *gomap.Map[int, int]
+		for _, order := range [][]int{{0, 1, 2}, {2, 1, 0}, {1, 0, 2}, {1, 2, 0, 1, 2}} {
+			merged := replicas[order[0]].Clone()
+			for _, i := range order[1:] {
+				merged.Merge(replicas[i])
+			}
+			merged.Merge(merged.Clone()) // idempotent
+			if expected == nil {
+				expected = merged.Map()
+			} else if !reflect.DeepEqual(*merged.Map(), *expected) {
+				t.Fatalf("Seed %d: expected merge order %v to produce %v, but got %v", seed, order, expected, merged.Map())
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package gomap
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ErrMergeConflict is returned by DeepMerge and DeepMergeFunc when two values at the same path cannot be merged.
+var ErrMergeConflict = errors.New("gomap: merge conflict")
+
+// MergeConflictError records the path and values that could not be merged.
+type MergeConflictError struct {
+	Path     string
+	Existing any
+	Incoming any
+}
+
+// Error describes the conflicting path and the types of its values.
+func (err *MergeConflictError) Error() string {
+	return fmt.Sprintf("%s at %s: cannot merge %T with %T", ErrMergeConflict, err.Path, err.Incoming, err.Existing)
+}
+
+// Unwrap returns ErrMergeConflict.
+func (err *MergeConflictError) Unwrap() error {
+	return ErrMergeConflict
+}
+
+// MergeStrategy resolves a value that exists at the same path in both maps passed to DeepMergeFunc.
+// It returns the merged value, or an error if the values cannot be merged.
+// Strategies are only called when at least one of the values is not a nested map; nested maps are merged recursively.
+type MergeStrategy func(path string, existing any, incoming any) (any, error)
+
+// MergeAppend appends the incoming slice to the existing slice. Both values must be slices of the same type.
+func MergeAppend(path string, existing any, incoming any) (any, error) {
+	existingValue, incomingValue := reflect.ValueOf(existing), reflect.ValueOf(incoming)
+	if existingValue.Kind() != reflect.Slice || incomingValue.Kind() != reflect.Slice || existingValue.Type() != incomingValue.Type() {
+		return nil, &MergeConflictError{Path: path, Existing: existing, Incoming: incoming}
+	}
+	merged := reflect.MakeSlice(existingValue.Type(), 0, existingValue.Len()+incomingValue.Len())
+	return reflect.AppendSlice(reflect.AppendSlice(merged, existingValue), incomingValue).Interface(), nil
+}
+
+// MergeKeepExisting keeps the existing value.
+func MergeKeepExisting(path string, existing any, incoming any) (any, error) {
+	return existing, nil
+}
+
+// MergeOverride replaces the existing value with the incoming value. Replacing a value with a value of
+// a different type, such as an int with a string or a []string with a []any, is reported as a conflict.
+// Nested maps of the types accepted by DeepMerge are compatible with each other, and nil values can be
+// replaced by, or replace, a value of any type.
+func MergeOverride(path string, existing any, incoming any) (any, error) {
+	if existing != nil && incoming != nil && reflect.TypeOf(existing) != reflect.TypeOf(incoming) {
+		_, existingMap := asMap(existing)
+		_, incomingMap := asMap(incoming)
+		if !existingMap || !incomingMap {
+			return nil, &MergeConflictError{Path: path, Existing: existing, Incoming: incoming}
+		}
+	}
+	return incoming, nil
+}
+
+// MergeUnion appends the elements of the incoming slice that are not already in the existing slice,
+// comparing elements using reflect.DeepEqual. Both values must be slices of the same type.
+func MergeUnion(path string, existing any, incoming any) (any, error) {
+	existingValue, incomingValue := reflect.ValueOf(existing), reflect.ValueOf(incoming)
+	if existingValue.Kind() != reflect.Slice || incomingValue.Kind() != reflect.Slice || existingValue.Type() != incomingValue.Type() {
+		return nil, &MergeConflictError{Path: path, Existing: existing, Incoming: incoming}
+	}
+	merged := reflect.AppendSlice(reflect.MakeSlice(existingValue.Type(), 0, existingValue.Len()), existingValue)
+	for i := 0; i < incomingValue.Len(); i++ {
+		element := incomingValue.Index(i).Interface()
+		found := false
+		for j := 0; j < merged.Len() && !found; j++ {
+			found = reflect.DeepEqual(merged.Index(j).Interface(), element)
+		}
+		if !found {
+			merged = reflect.Append(merged, incomingValue.Index(i))
+		}
+	}
+	return merged.Interface(), nil
+}
+
+// DeepMerge merges the other map into the current map, recursing into nested maps and replacing other values
+// using MergeOverride. Nested maps may be of type Map[string, any], *Map[string, any] or map[string]any.
+// All conflicts are reported together and the map is left unchanged if any occur.
+//
+//	// Create two new Map instances.
+//	defaults := &gomap.Map[string, any]{"server": map[string]any{"port": 8080, "host": "localhost"}}
+//	overrides := &gomap.Map[string, any]{"server": map[string]any{"port": 9090}}
+//	err := gomap.DeepMerge(defaults, overrides) // &map[server:map[host:localhost port:9090]]
+func DeepMerge(gomap *Map[string, any], other *Map[string, any]) error {
+	return DeepMergeFunc(gomap, other, func(path string) MergeStrategy {
+		return MergeOverride
+	})
+}
+
+// DeepMergeFunc merges the other map into the current map, recursing into nested maps and resolving other values
+// using the MergeStrategy returned for their dot-separated path. A nil MergeStrategy defaults to MergeOverride.
+// All conflicts are reported together and the map is left unchanged if any occur. Nested maps taken from
+// the other map are copied, so the result never shares them with the other map.
+//
+//	// Create two new Map instances.
+//	base := &gomap.Map[string, any]{"name": "api", "tags": []string{"a"}}
+//	layer := &gomap.Map[string, any]{"name": "web", "tags": []string{"a", "b"}}
+//	err := gomap.DeepMergeFunc(base, layer, func(path string) gomap.MergeStrategy {
+//		switch path {
+//		case "tags":
+//			return gomap.MergeUnion
+//		case "name":
+//			return gomap.MergeKeepExisting
+//		}
+//		return gomap.MergeOverride
+//	}) // &map[name:api tags:[a b]]
+func DeepMergeFunc(gomap *Map[string, any], other *Map[string, any], fn func(path string) MergeStrategy) error {
+	merged, errs := deepMerge("", gomap, other, fn)
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	for key := range *gomap {
+		delete(*gomap, key)
+	}
+	gomap.AddMany(merged)
+	return nil
+}
+
+// deepMerge returns a new map holding the result of merging other into gomap without modifying either map.
+func deepMerge(prefix string, gomap *Map[string, any], other *Map[string, any], fn func(path string) MergeStrategy) (Map[string, any], []error) {
+	var errs []error
+	merged := make(Map[string, any], gomap.Length())
+	merged.AddMany(*gomap)
+	keys := []string(*other.Keys())
+	sort.Strings(keys)
+	for _, key := range keys {
+		incoming := other.Fetch(key)
+		existing, ok := merged.Get(key)
+		if !ok {
+			merged.Add(key, copyMaps(incoming))
+			continue
+		}
+		path := key
+		if prefix != "" {
+			path = prefix + PathSeparator + key
+		}
+		existingMap, existingOK := asMap(existing)
+		incomingMap, incomingOK := asMap(incoming)
+		if existingOK && incomingOK {
+			child, childErrs := deepMerge(path, existingMap, incomingMap, fn)
+			errs = append(errs, childErrs...)
+			merged.Add(key, asMapType(existing, child))
+			continue
+		}
+		strategy := fn(path)
+		if strategy == nil {
+			strategy = MergeOverride
+		}
+		value, err := strategy(path, existing, incoming)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		merged.Add(key, copyMaps(value))
+	}
+	return merged, errs
+}
+
+// copyMaps returns a copy of the value in which nested maps are copied recursively, so that later changes
+// to the merged map, such as a SetPath or another DeepMerge, do not modify the maps passed to DeepMergeFunc.
+// Values that are not nested maps are returned as they are.
+func copyMaps(value any) any {
+	gomap, ok := asMap(value)
+	if !ok {
+		return value
+	}
+	newMap := make(Map[string, any], gomap.Length())
+	for key, value := range *gomap {
+		newMap.Add(key, copyMaps(value))
+	}
+	return asMapType(value, newMap)
+}
+
+// asMapType converts the map to the nested map type of the original value.
+func asMapType(original any, gomap Map[string, any]) any {
+	switch original.(type) {
+	case map[string]any:
+		return map[string]any(gomap)
+	case *Map[string, any]:
+		return &gomap
+	}
+	return gomap
+}
@@ -0,0 +1,187 @@
+package gomap_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// TestDeepMerge tests DeepMerge.
+func TestDeepMerge(t *testing.T) {
+	defaults := &gomap.Map[string, any]{
+		"name": "api",
+		"server": map[string]any{
+			"host": "localhost",
+			"port": 8080,
+			"tls":  map[string]any{"enabled": false}}}
+	file := &gomap.Map[string, any]{
+		"server": map[string]any{
+			"port": 9090,
+			"tls":  map[string]any{"cert": "a.pem"}}}
+
+	if err := gomap.DeepMerge(defaults, file); err != nil {
+		t.Fatalf("Expected DeepMerge to succeed, but got %v", err)
+	}
+	expected := &gomap.Map[string, any]{
+		"name": "api",
+		"server": map[string]any{
+			"host": "localhost",
+			"port": 9090,
+			"tls":  map[string]any{"enabled": false, "cert": "a.pem"}}}
+	if !defaults.Equal(expected) {
+		t.Errorf("Expected %v, but got %v", expected, defaults)
+	}
+
+	// The merged subtrees are new maps; the inputs' nested maps are not modified.
+	if len(file.Fetch("server").(map[string]any)["tls"].(map[string]any)) != 1 {
+		t.Errorf("Expected the incoming map to be unchanged")
+	}
+}
+
+// TestDeepMergeCopiesNewMaps tests that nested maps added by DeepMerge are not shared with the other map.
+func TestDeepMergeCopiesNewMaps(t *testing.T) {
+	tls := map[string]any{"enabled": true}
+	other := &gomap.Map[string, any]{
+		"server":  map[string]any{"tls": tls},
+		"logging": &gomap.Map[string, any]{"level": "info"}}
+	base := &gomap.Map[string, any]{"name": "api"}
+	if err := gomap.DeepMerge(base, other); err != nil {
+		t.Fatalf("Expected DeepMerge to succeed, but got %v", err)
+	}
+	base.Fetch("server").(map[string]any)["tls"].(map[string]any)["enabled"] = false
+	base.Fetch("logging").(*gomap.Map[string, any]).Add("level", "debug")
+	if err := gomap.DeepMerge(base, &gomap.Map[string, any]{"server": map[string]any{"tls": map[string]any{"cert": "a.pem"}}}); err != nil {
+		t.Fatalf("Expected DeepMerge to succeed, but got %v", err)
+	}
+	if len(tls) != 1 || tls["enabled"] != true || other.Fetch("logging").(*gomap.Map[string, any]).Fetch("level") != "info" {
+		t.Errorf("Expected the other map to be unchanged, but got %v", other)
+	}
+}
+
+// TestDeepMergeConflict tests that DeepMerge reports incompatible types.
+func TestDeepMergeConflict(t *testing.T) {
+	base := &gomap.Map[string, any]{
+		"server": map[string]any{"port": 8080},
+		"tags":   []string{"a"},
+		"name":   "api"}
+	layer := &gomap.Map[string, any]{
+		"server": "localhost:8080",
+		"tags":   "a",
+		"name":   "web"}
+
+	err := gomap.DeepMerge(base, layer)
+	if !errors.Is(err, gomap.ErrMergeConflict) {
+		t.Fatalf("Expected ErrMergeConflict, but got %v", err)
+	}
+	var conflict *gomap.MergeConflictError
+	if !errors.As(err, &conflict) || conflict.Path != "server" {
+		t.Errorf("Expected the first conflict at 'server', but got %v", conflict)
+	}
+	if !strings.Contains(err.Error(), "tags") {
+		t.Errorf("Expected all conflicts to be reported, but got %v", err)
+	}
+	if base.Fetch("name") != "api" {
+		t.Errorf("Expected the map to be unchanged after a conflict")
+	}
+}
+
+// TestDeepMergeFunc tests DeepMergeFunc with per-path strategies.
+func TestDeepMergeFunc(t *testing.T) {
+	base := &gomap.Map[string, any]{
+		"name": "api",
+		"logging": &gomap.Map[string, any]{
+			"outputs": []string{"stdout"},
+			"tags":    []string{"a", "b"}},
+		"version": 1}
+	layer := &gomap.Map[string, any]{
+		"name": "web",
+		"logging": map[string]any{
+			"outputs": []string{"file"},
+			"tags":    []string{"b", "c"}},
+		"version": 2}
+
+	err := gomap.DeepMergeFunc(base, layer, func(path string) gomap.MergeStrategy {
+		switch path {
+		case "name":
+			return gomap.MergeKeepExisting
+		case "logging.outputs":
+			return gomap.MergeAppend
+		case "logging.tags":
+			return gomap.MergeUnion
+		case "version":
+			return func(path string, existing any, incoming any) (any, error) {
+				return existing.(int) + incoming.(int), nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected DeepMergeFunc to succeed, but got %v", err)
+	}
+	if base.Fetch("name") != "api" || base.Fetch("version") != 3 {
+		t.Errorf("Expected name 'api' and version 3, but got %v", base)
+	}
+	logging := base.Fetch("logging").(*gomap.Map[string, any])
+	if outputs := logging.Fetch("outputs"); !reflect.DeepEqual(outputs, []string{"stdout", "file"}) {
+		t.Errorf("Expected appended outputs, but got %v", outputs)
+	}
+	if tags := logging.Fetch("tags"); !reflect.DeepEqual(tags, []string{"a", "b", "c"}) {
+		t.Errorf("Expected union of tags, but got %v", tags)
+	}
+}
+
+// TestMergeStrategyConflict tests that slice strategies reject values that are not slices of the same type.
+func TestMergeStrategyConflict(t *testing.T) {
+	for _, strategy := range []gomap.MergeStrategy{gomap.MergeAppend, gomap.MergeUnion} {
+		if _, err := strategy("tags", []string{"a"}, []int{1}); !errors.Is(err, gomap.ErrMergeConflict) {
+			t.Errorf("Expected ErrMergeConflict for different slice types, but got %v", err)
+		}
+		if _, err := strategy("tags", []string{"a"}, nil); !errors.Is(err, gomap.ErrMergeConflict) {
+			t.Errorf("Expected ErrMergeConflict for a nil value, but got %v", err)
+		}
+	}
+	if value, err := gomap.MergeOverride("name", nil, "api"); err != nil || value != "api" {
+		t.Errorf("Expected override of a nil value to succeed, but got %v, %v", value, err)
+	}
+}
+
+// TestMergeOverrideConflict tests that MergeOverride rejects values of different types.
+func TestMergeOverrideConflict(t *testing.T) {
+	conflicts := []struct {
+		existing, incoming any
+	}{
+		{8080, "8080"},
+		{true, 1.0},
+		{1, 1.0},
+		{[]string{"a"}, []any{"a"}},
+		{map[string]any{"a": 1}, []any{"a"}},
+		{"api", map[string]any{"a": 1}},
+	}
+	for _, conflict := range conflicts {
+		if _, err := gomap.MergeOverride("key", conflict.existing, conflict.incoming); !errors.Is(err, gomap.ErrMergeConflict) {
+			t.Errorf("Expected ErrMergeConflict replacing %T with %T, but got %v", conflict.existing, conflict.incoming, err)
+		}
+	}
+	compatible := []struct {
+		existing, incoming any
+	}{
+		{8080, 9090},
+		{nil, "api"},
+		{"api", nil},
+		{map[string]any{"a": 1}, &gomap.Map[string, any]{"b": 2}},
+	}
+	for _, value := range compatible {
+		if result, err := gomap.MergeOverride("key", value.existing, value.incoming); err != nil || !reflect.DeepEqual(result, value.incoming) {
+			t.Errorf("Expected %T to replace %T, but got %v, %v", value.incoming, value.existing, result, err)
+		}
+	}
+	base := &gomap.Map[string, any]{"port": 8080, "debug": false}
+	err := gomap.DeepMerge(base, &gomap.Map[string, any]{"port": "9090", "debug": true})
+	var conflict *gomap.MergeConflictError
+	if !errors.As(err, &conflict) || conflict.Path != "port" || base.Fetch("port") != 8080 {
+		t.Errorf("Expected a conflict at 'port' and an unchanged map, but got %v and %v", err, base)
+	}
+}
@@ -0,0 +1,272 @@
+package gomap
+
+import (
+	"reflect"
+
+	"github.com/lindsaygelle/slice"
+)
+
+// MultiMap is a map that associates each key with a collection of values.
+// SliceMultiMap keeps every value in insertion order, allowing duplicates,
+// while SetMultiMap keeps only unique values per key, also in insertion order.
+type MultiMap[K comparable, V any] interface {
+	// ContainsEntry checks if the value is associated with the key.
+	ContainsEntry(key K, value V) bool
+	// Each executes the provided function for each key-value pair.
+	Each(fn func(key K, value V))
+	// Get returns the values associated with the key, or an empty slice if the key is not present.
+	Get(key K) *slice.Slice[V]
+	// Has checks if at least one value is associated with the key.
+	Has(key K) bool
+	// KeyCount returns the number of distinct keys.
+	KeyCount() int
+	// Keys returns the distinct keys.
+	Keys() *slice.Slice[K]
+	// Put associates the value with the key and reports whether the multimap changed.
+	Put(key K, value V) bool
+	// PutAll associates all values with the key and reports whether the multimap changed.
+	PutAll(key K, values ...V) bool
+	// Remove removes a single association of the value with the key and reports whether it was present.
+	Remove(key K, value V) bool
+	// RemoveAll removes the key and returns the values that were associated with it.
+	RemoveAll(key K) *slice.Slice[V]
+	// Size returns the total number of key-value pairs.
+	Size() int
+}
+
+// SliceMultiMap is a MultiMap that stores the values of each key in a slice.
+// Values keep their insertion order and duplicates are allowed. Values are compared using reflect.DeepEqual.
+type SliceMultiMap[K comparable, V any] struct {
+	gomap Map[K, []V]
+	size  int
+}
+
+// NewSliceMultiMap creates an empty SliceMultiMap.
+//
+//	// Create a new SliceMultiMap instance.
+//	multiMap := gomap.NewSliceMultiMap[string, string]()
+//	multiMap.Put("Accept", "text/html")
+//	multiMap.Put("Accept", "application/json")
+//	values := multiMap.Get("Accept") // {"text/html", "application/json"}
+func NewSliceMultiMap[K comparable, V any]() *SliceMultiMap[K, V] {
+	return &SliceMultiMap[K, V]{gomap: make(Map[K, []V])}
+}
+
+// ContainsEntry checks if the value is associated with the key.
+func (multiMap *SliceMultiMap[K, V]) ContainsEntry(key K, value V) bool {
+	for _, v := range multiMap.gomap.Fetch(key) {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Each executes the provided function for each key-value pair. Values of a key are visited in insertion order.
+func (multiMap *SliceMultiMap[K, V]) Each(fn func(key K, value V)) {
+	multiMap.gomap.Each(func(key K, values []V) {
+		for _, value := range values {
+			fn(key, value)
+		}
+	})
+}
+
+// Get returns a copy of the values associated with the key in insertion order.
+func (multiMap *SliceMultiMap[K, V]) Get(key K) *slice.Slice[V] {
+	values := append(slice.Slice[V]{}, multiMap.gomap.Fetch(key)...)
+	return &values
+}
+
+// Has checks if at least one value is associated with the key.
+func (multiMap *SliceMultiMap[K, V]) Has(key K) bool {
+	return multiMap.gomap.Has(key)
+}
+
+// KeyCount returns the number of distinct keys.
+func (multiMap *SliceMultiMap[K, V]) KeyCount() int {
+	return multiMap.gomap.Length()
+}
+
+// Keys returns the distinct keys.
+func (multiMap *SliceMultiMap[K, V]) Keys() *slice.Slice[K] {
+	return multiMap.gomap.Keys()
+}
+
+// Put appends the value to the values associated with the key. It always returns true.
+func (multiMap *SliceMultiMap[K, V]) Put(key K, value V) bool {
+	return multiMap.PutAll(key, value)
+}
+
+// PutAll appends the values to the values associated with the key and reports whether any values were provided.
+func (multiMap *SliceMultiMap[K, V]) PutAll(key K, values ...V) bool {
+	if len(values) == 0 {
+		return false
+	}
+	multiMap.gomap.Add(key, append(multiMap.gomap.Fetch(key), values...))
+	multiMap.size += len(values)
+	return true
+}
+
+// Remove removes the first occurrence of the value from the values associated with the key.
+func (multiMap *SliceMultiMap[K, V]) Remove(key K, value V) bool {
+	values := multiMap.gomap.Fetch(key)
+	for i, v := range values {
+		if reflect.DeepEqual(v, value) {
+			multiMap.size--
+			if len(values) == 1 {
+				multiMap.gomap.Delete(key)
+			} else {
+				multiMap.gomap.Add(key, append(values[:i:i], values[i+1:]...))
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveAll removes the key and returns the values that were associated with it.
+func (multiMap *SliceMultiMap[K, V]) RemoveAll(key K) *slice.Slice[V] {
+	values := slice.Slice[V](multiMap.gomap.Pop(key))
+	multiMap.size -= values.Length()
+	return &values
+}
+
+// Size returns the total number of key-value pairs.
+func (multiMap *SliceMultiMap[K, V]) Size() int {
+	return multiMap.size
+}
+
+// valueSet is a set of values that remembers insertion order.
+type valueSet[V comparable] struct {
+	index  map[V]int
+	values []V
+}
+
+// SetMultiMap is a MultiMap that stores the values of each key in a set.
+// Each value is associated with a key at most once, and values keep their insertion order.
+type SetMultiMap[K comparable, V comparable] struct {
+	gomap Map[K, *valueSet[V]]
+	size  int
+}
+
+// NewSetMultiMap creates an empty SetMultiMap.
+//
+//	// Create a new SetMultiMap instance.
+//	multiMap := gomap.NewSetMultiMap[string, string]()
+//	multiMap.Put("tags", "go")
+//	multiMap.Put("tags", "go") // Returns false, "go" is already associated with "tags"
+//	size := multiMap.Size() // 1
+func NewSetMultiMap[K comparable, V comparable]() *SetMultiMap[K, V] {
+	return &SetMultiMap[K, V]{gomap: make(Map[K, *valueSet[V]])}
+}
+
+// ContainsEntry checks if the value is associated with the key.
+func (multiMap *SetMultiMap[K, V]) ContainsEntry(key K, value V) bool {
+	set, ok := multiMap.gomap.Get(key)
+	if !ok {
+		return false
+	}
+	_, ok = set.index[value]
+	return ok
+}
+
+// Each executes the provided function for each key-value pair. Values of a key are visited in insertion order.
+func (multiMap *SetMultiMap[K, V]) Each(fn func(key K, value V)) {
+	multiMap.gomap.Each(func(key K, set *valueSet[V]) {
+		for _, value := range set.values {
+			fn(key, value)
+		}
+	})
+}
+
+// Get returns a copy of the values associated with the key in insertion order.
+func (multiMap *SetMultiMap[K, V]) Get(key K) *slice.Slice[V] {
+	values := slice.Slice[V]{}
+	if set, ok := multiMap.gomap.Get(key); ok {
+		values = append(values, set.values...)
+	}
+	return &values
+}
+
+// Has checks if at least one value is associated with the key.
+func (multiMap *SetMultiMap[K, V]) Has(key K) bool {
+	return multiMap.gomap.Has(key)
+}
+
+// KeyCount returns the number of distinct keys.
+func (multiMap *SetMultiMap[K, V]) KeyCount() int {
+	return multiMap.gomap.Length()
+}
+
+// Keys returns the distinct keys.
+func (multiMap *SetMultiMap[K, V]) Keys() *slice.Slice[K] {
+	return multiMap.gomap.Keys()
+}
+
+// Put associates the value with the key and reports whether it was not already associated.
+func (multiMap *SetMultiMap[K, V]) Put(key K, value V) bool {
+	return multiMap.PutAll(key, value)
+}
+
+// PutAll associates the values with the key and reports whether any of them were not already associated.
+func (multiMap *SetMultiMap[K, V]) PutAll(key K, values ...V) bool {
+	if len(values) == 0 {
+		return false
+	}
+	set, ok := multiMap.gomap.Get(key)
+	if !ok {
+		set = &valueSet[V]{index: make(map[V]int)}
+	}
+	changed := false
+	for _, value := range values {
+		if _, ok := set.index[value]; ok {
+			continue
+		}
+		set.index[value] = len(set.values)
+		set.values = append(set.values, value)
+		multiMap.size++
+		changed = true
+	}
+	if changed {
+		multiMap.gomap.Add(key, set)
+	}
+	return changed
+}
+
+// Remove removes the association of the value with the key and reports whether it was present.
+func (multiMap *SetMultiMap[K, V]) Remove(key K, value V) bool {
+	set, ok := multiMap.gomap.Get(key)
+	if !ok {
+		return false
+	}
+	i, ok := set.index[value]
+	if !ok {
+		return false
+	}
+	multiMap.size--
+	if len(set.values) == 1 {
+		multiMap.gomap.Delete(key)
+		return true
+	}
+	delete(set.index, value)
+	set.values = append(set.values[:i], set.values[i+1:]...)
+	for j := i; j < len(set.values); j++ {
+		set.index[set.values[j]] = j
+	}
+	return true
+}
+
+// RemoveAll removes the key and returns the values that were associated with it.
+func (multiMap *SetMultiMap[K, V]) RemoveAll(key K) *slice.Slice[V] {
+	values := slice.Slice[V]{}
+	if set, ok := multiMap.gomap.PopOK(key); ok {
+		values = set.values
+		multiMap.size -= len(values)
+	}
+	return &values
+}
+
+// Size returns the total number of key-value pairs.
+func (multiMap *SetMultiMap[K, V]) Size() int {
+	return multiMap.size
+}
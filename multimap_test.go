@@ -0,0 +1,119 @@
+package gomap_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+	"github.com/lindsaygelle/slice"
+)
+
+// TestSliceMultiMap tests SliceMultiMap.
+func TestSliceMultiMap(t *testing.T) {
+	var multiMap gomap.MultiMap[string, string] = gomap.NewSliceMultiMap[string, string]()
+
+	// Test case 1: Values keep insertion order and allow duplicates.
+	multiMap.Put("Accept", "text/html")
+	multiMap.PutAll("Accept", "application/json", "text/html")
+	multiMap.Put("Host", "example.com")
+	if values := multiMap.Get("Accept"); !reflect.DeepEqual(*values, slice.Slice[string]{"text/html", "application/json", "text/html"}) {
+		t.Errorf("Expected values in insertion order, but got %v", values)
+	}
+	if multiMap.Size() != 4 || multiMap.KeyCount() != 2 {
+		t.Errorf("Expected size 4 and key count 2, but got %d and %d", multiMap.Size(), multiMap.KeyCount())
+	}
+	if !multiMap.ContainsEntry("Accept", "application/json") || multiMap.ContainsEntry("Host", "text/html") {
+		t.Errorf("Expected ContainsEntry to match only associated values")
+	}
+
+	// Test case 2: Remove removes a single occurrence.
+	if !multiMap.Remove("Accept", "text/html") {
+		t.Errorf("Expected Remove to report the value was present")
+	}
+	if values := multiMap.Get("Accept"); !reflect.DeepEqual(*values, slice.Slice[string]{"application/json", "text/html"}) {
+		t.Errorf("Expected the first occurrence to be removed, but got %v", values)
+	}
+	if multiMap.Remove("Accept", "text/plain") {
+		t.Errorf("Expected Remove to report a missing value")
+	}
+
+	// Test case 3: RemoveAll removes the key and returns its values.
+	if values := multiMap.RemoveAll("Accept"); values.Length() != 2 {
+		t.Errorf("Expected 2 removed values, but got %v", values)
+	}
+	if multiMap.Has("Accept") || multiMap.Size() != 1 || multiMap.KeyCount() != 1 {
+		t.Errorf("Expected only 'Host' to remain, but got size %d", multiMap.Size())
+	}
+
+	// Test case 4: Removing the last value removes the key.
+	multiMap.Remove("Host", "example.com")
+	if multiMap.Has("Host") || multiMap.Size() != 0 {
+		t.Errorf("Expected multimap to be empty")
+	}
+}
+
+// TestSliceMultiMapGet tests that SliceMultiMap.Get returns a copy.
+func TestSliceMultiMapGet(t *testing.T) {
+	multiMap := gomap.NewSliceMultiMap[string, int]()
+	multiMap.PutAll("numbers", 1, 2)
+	values := multiMap.Get("numbers")
+	values.Replace(0, 10)
+	if multiMap.Get("numbers").Fetch(0) != 1 {
+		t.Errorf("Expected changes to the returned slice not to affect the multimap")
+	}
+	if multiMap.Get("missing").Length() != 0 {
+		t.Errorf("Expected an empty slice for a missing key")
+	}
+}
+
+// TestSetMultiMap tests SetMultiMap.
+func TestSetMultiMap(t *testing.T) {
+	var multiMap gomap.MultiMap[string, string] = gomap.NewSetMultiMap[string, string]()
+
+	// Test case 1: Values are unique per key.
+	if !multiMap.Put("tags", "go") || multiMap.Put("tags", "go") {
+		t.Errorf("Expected only the first Put of a value to change the multimap")
+	}
+	if !multiMap.PutAll("tags", "go", "maps", "generics") {
+		t.Errorf("Expected PutAll with new values to change the multimap")
+	}
+	multiMap.Put("owners", "go")
+	if values := multiMap.Get("tags"); !reflect.DeepEqual(*values, slice.Slice[string]{"go", "maps", "generics"}) {
+		t.Errorf("Expected unique values in insertion order, but got %v", values)
+	}
+	if multiMap.Size() != 4 || multiMap.KeyCount() != 2 {
+		t.Errorf("Expected size 4 and key count 2, but got %d and %d", multiMap.Size(), multiMap.KeyCount())
+	}
+
+	// Test case 2: Remove keeps the order of the remaining values.
+	if !multiMap.Remove("tags", "go") || multiMap.ContainsEntry("tags", "go") {
+		t.Errorf("Expected 'go' to be removed from 'tags'")
+	}
+	if !multiMap.ContainsEntry("owners", "go") {
+		t.Errorf("Expected 'go' to remain associated with 'owners'")
+	}
+	multiMap.Put("tags", "go")
+	if values := multiMap.Get("tags"); !reflect.DeepEqual(*values, slice.Slice[string]{"maps", "generics", "go"}) {
+		t.Errorf("Expected re-added value at the end, but got %v", values)
+	}
+	if !multiMap.Remove("tags", "maps") || !multiMap.ContainsEntry("tags", "generics") || !multiMap.ContainsEntry("tags", "go") {
+		t.Errorf("Expected remaining values to stay associated after removal")
+	}
+
+	// Test case 3: RemoveAll removes the key and returns its values.
+	if values := multiMap.RemoveAll("tags"); values.Length() != 2 {
+		t.Errorf("Expected 2 removed values, but got %v", values)
+	}
+	if multiMap.Size() != 1 || multiMap.KeyCount() != 1 {
+		t.Errorf("Expected size 1, but got %d", multiMap.Size())
+	}
+
+	// Test case 4: Each visits every pair.
+	count := 0
+	multiMap.Each(func(key string, value string) {
+		count++
+	})
+	if count != 1 {
+		t.Errorf("Expected Each to visit 1 pair, but got %d", count)
+	}
+}
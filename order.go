@@ -0,0 +1,127 @@
+package gomap
+
+import (
+	"cmp"
+	"reflect"
+)
+
+// keyOrder returns a strict total order over the keys of type K, used to break ties between keys that
+// a less function does not order, so that sorted results do not depend on map iteration order.
+// Ordered types are compared using cmp.Compare, and booleans as false before true.
+// Arrays and structs are compared element by element and field by field, and interfaces by their dynamic type
+// and then their value. Pointers and channels are compared by address, which is fixed for the life of the value
+// but differs between runs.
+func keyOrder[K comparable]() func(a K, b K) int {
+	if compare, ok := keyOrderScalar[K]().(func(a K, b K) int); ok {
+		return compare
+	}
+	return func(a K, b K) int {
+		return compareValues(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+	}
+}
+
+// keyOrderScalar returns cmp.Compare if K is a predeclared ordered type, or nil otherwise.
+func keyOrderScalar[K comparable]() any {
+	switch any((*K)(nil)).(type) {
+	case *int:
+		return cmp.Compare[int]
+	case *int8:
+		return cmp.Compare[int8]
+	case *int16:
+		return cmp.Compare[int16]
+	case *int32:
+		return cmp.Compare[int32]
+	case *int64:
+		return cmp.Compare[int64]
+	case *uint:
+		return cmp.Compare[uint]
+	case *uint8:
+		return cmp.Compare[uint8]
+	case *uint16:
+		return cmp.Compare[uint16]
+	case *uint32:
+		return cmp.Compare[uint32]
+	case *uint64:
+		return cmp.Compare[uint64]
+	case *uintptr:
+		return cmp.Compare[uintptr]
+	case *float32:
+		return cmp.Compare[float32]
+	case *float64:
+		return cmp.Compare[float64]
+	case *string:
+		return cmp.Compare[string]
+	}
+	return nil
+}
+
+// compareValues compares two values of the same comparable type.
+func compareValues(a reflect.Value, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Bool:
+		return compareBools(a.Bool(), b.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		if c := cmp.Compare(real(a.Complex()), real(b.Complex())); c != 0 {
+			return c
+		}
+		return cmp.Compare(imag(a.Complex()), imag(b.Complex()))
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return cmp.Compare(a.Pointer(), b.Pointer())
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if c := compareValues(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if c := compareValues(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return compareBools(!a.IsNil(), !b.IsNil())
+		}
+		a, b = a.Elem(), b.Elem()
+		if a.Type() != b.Type() {
+			if c := cmp.Compare(a.Type().String(), b.Type().String()); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Type().PkgPath(), b.Type().PkgPath())
+		}
+		return compareValues(a, b)
+	}
+	return 0
+}
+
+// compareBools orders false before true.
+func compareBools(a bool, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
+
+// lessThenKey returns a less function that orders the pairs using less, and pairs that less does not order
+// by their keys, so that no two pairs of a map are tied.
+func lessThenKey[K comparable, V any](less func(a Entry[K, V], b Entry[K, V]) bool) func(a Entry[K, V], b Entry[K, V]) bool {
+	compare := keyOrder[K]()
+	return func(a Entry[K, V], b Entry[K, V]) bool {
+		if less(a, b) {
+			return true
+		}
+		return !less(b, a) && compare(a.Key, b.Key) < 0
+	}
+}
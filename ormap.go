@@ -0,0 +1,133 @@
+package gomap
+
+import (
+	"github.com/lindsaygelle/slice"
+)
+
+// ORMap is an observed-remove map with add-wins semantics, a conflict-free replicated data type (CRDT).
+// Every addition is tagged with a unique hybrid logical clock timestamp, and a deletion removes only the additions
+// its replica has observed, so an addition made concurrently with a deletion survives the merge. If concurrent
+// additions give a key different values, the value with the latest timestamp is used.
+//
+// Merge is commutative, associative and idempotent, so replicas that have merged the same updates hold the same
+// key-value pairs regardless of the order in which the merges happened. The tags of removed additions are kept
+// so that removals are replicated.
+type ORMap[K comparable, V any] struct {
+	adds    map[K]map[HLC]V
+	clock   *Clock
+	removed map[HLC]struct{}
+}
+
+// NewORMap creates an empty ORMap for a replica that tags its additions using the clock.
+// Each replica must use a Clock with a unique node name.
+//
+//	// Create two replicas.
+//	a := gomap.NewORMap[string, int](gomap.NewClock("a"))
+//	a.Add("apple", 5)
+//	b := gomap.NewORMap[string, int](gomap.NewClock("b")).Merge(a)
+//	a.Delete("apple")
+//	b.Add("apple", 3) // concurrent with the deletion, so it wins
+//	a.Merge(b)
+//	value, _ := a.Get("apple") // 3
+func NewORMap[K comparable, V any](clock *Clock) *ORMap[K, V] {
+	return &ORMap[K, V]{adds: make(map[K]map[HLC]V), clock: clock, removed: make(map[HLC]struct{})}
+}
+
+// Add sets the value of the key, replacing the additions of the key observed by the replica.
+func (orMap *ORMap[K, V]) Add(key K, value V) *ORMap[K, V] {
+	orMap.Delete(key)
+	orMap.adds[key] = map[HLC]V{orMap.clock.Now(): value}
+	return orMap
+}
+
+// Clone returns a copy of the replica that shares its clock.
+func (orMap *ORMap[K, V]) Clone() *ORMap[K, V] {
+	return NewORMap[K, V](orMap.clock).Merge(orMap)
+}
+
+// Delete removes the key by removing the additions of the key observed by the replica.
+func (orMap *ORMap[K, V]) Delete(key K) *ORMap[K, V] {
+	for tag := range orMap.adds[key] {
+		orMap.removed[tag] = struct{}{}
+	}
+	delete(orMap.adds, key)
+	return orMap
+}
+
+// Each executes the provided function for each key-value pair in the map.
+func (orMap *ORMap[K, V]) Each(fn func(key K, value V)) *ORMap[K, V] {
+	for key := range orMap.adds {
+		value, _ := orMap.Get(key)
+		fn(key, value)
+	}
+	return orMap
+}
+
+// Get retrieves the value associated with the provided key and a boolean indicating whether the key exists.
+func (orMap *ORMap[K, V]) Get(key K) (V, bool) {
+	var value V
+	var latest HLC
+	tags, ok := orMap.adds[key]
+	for tag, v := range tags {
+		if latest.Before(tag) {
+			latest, value = tag, v
+		}
+	}
+	return value, ok
+}
+
+// Has checks if the provided key exists in the map.
+func (orMap *ORMap[K, V]) Has(key K) bool {
+	_, ok := orMap.adds[key]
+	return ok
+}
+
+// Keys returns a slice containing all the keys present in the map.
+func (orMap *ORMap[K, V]) Keys() *slice.Slice[K] {
+	keys := make(slice.Slice[K], 0, len(orMap.adds))
+	for key := range orMap.adds {
+		keys.Append(key)
+	}
+	return &keys
+}
+
+// Length returns the number of key-value pairs in the map.
+func (orMap *ORMap[K, V]) Length() int {
+	return len(orMap.adds)
+}
+
+// Map returns a new Map containing the key-value pairs of the replica.
+func (orMap *ORMap[K, V]) Map() *Map[K, V] {
+	newMap := make(Map[K, V], len(orMap.adds))
+	orMap.Each(func(key K, value V) {
+		newMap.Add(key, value)
+	})
+	return &newMap
+}
+
+// Merge merges the additions and removals of another replica into the map.
+func (orMap *ORMap[K, V]) Merge(other *ORMap[K, V]) *ORMap[K, V] {
+	for tag := range other.removed {
+		orMap.removed[tag] = struct{}{}
+	}
+	for key, tags := range other.adds {
+		for tag, value := range tags {
+			orMap.clock.Observe(tag)
+			if orMap.adds[key] == nil {
+				orMap.adds[key] = make(map[HLC]V)
+			}
+			orMap.adds[key][tag] = value
+		}
+	}
+	for key, tags := range orMap.adds {
+		for tag := range tags {
+			if _, ok := orMap.removed[tag]; ok {
+				delete(tags, tag)
+			}
+		}
+		if len(tags) == 0 {
+			delete(orMap.adds, key)
+		}
+	}
+	return orMap
+}
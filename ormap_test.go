@@ -0,0 +1,103 @@
+package gomap_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// TestORMapAdd tests ORMap.Add.
+func TestORMapAdd(t *testing.T) {
+	orMap := gomap.NewORMap[string, int](gomap.NewClock("a"))
+	orMap.Add("a", 1).Add("a", 2).Add("b", 3)
+	if value, ok := orMap.Get("a"); !ok || value != 2 {
+		t.Errorf("Expected (2, true), but got (%v, %v)", value, ok)
+	}
+	if orMap.Length() != 2 {
+		t.Errorf("Expected length 2, but got %d", orMap.Length())
+	}
+}
+
+// TestORMapDelete tests ORMap.Delete.
+func TestORMapDelete(t *testing.T) {
+	orMap := gomap.NewORMap[string, int](gomap.NewClock("a"))
+	orMap.Add("a", 1).Add("b", 2).Delete("a").Delete("c")
+	if orMap.Has("a") || orMap.Has("c") {
+		t.Errorf("Expected deleted keys to be absent, but got %v", orMap.Map())
+	}
+	if value, ok := orMap.Get("a"); ok || value != 0 {
+		t.Errorf("Expected (0, false), but got (%v, %v)", value, ok)
+	}
+	if keys := []string(*orMap.Keys()); !reflect.DeepEqual(keys, []string{"b"}) {
+		t.Errorf("Expected [b], but got %v", keys)
+	}
+}
+
+// TestORMapMerge tests ORMap.Merge.
+func TestORMapMerge(t *testing.T) {
+	a := gomap.NewORMap[string, int](newFixedClock("a"))
+	b := gomap.NewORMap[string, int](newFixedClock("b"))
+	a.Add("x", 1).Add("y", 1)
+	b.Merge(a)
+	a.Delete("x")
+	b.Add("x", 2) // concurrent with the deletion, so it wins
+	b.Delete("y")
+	a.Merge(b)
+	b.Merge(a)
+	for _, replica := range []*gomap.ORMap[string, int]{a, b} {
+		if !reflect.DeepEqual(*replica.Map(), gomap.Map[string, int]{"x": 2}) {
+			t.Errorf("Expected map[x:2], but got %v", replica.Map())
+		}
+	}
+	a.Add("z", 1)
+	b.Add("z", 2) // concurrent additions keep the value with the latest timestamp
+	a.Merge(b)
+	b.Merge(a)
+	if value := a.Map().Fetch("z"); value != 2 || b.Map().Fetch("z") != 2 {
+		t.Errorf("Expected 2, but got %v", value)
+	}
+	b.Delete("z") // removes both observed additions
+	a.Merge(b)
+	if a.Has("z") {
+		t.Errorf("Expected z to be deleted, but got %v", a.Map())
+	}
+}
+
+// TestORMapConvergence tests that ORMap replicas converge regardless of operation interleaving and merge order.
+func TestORMapConvergence(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		random := rand.New(rand.NewSource(seed))
+		replicas := make([]*gomap.ORMap[int, int], 3)
+		for i := range replicas {
+			replicas[i] = gomap.NewORMap[int, int](newFixedClock(fmt.Sprint(i)))
+		}
+		for i := 0; i < 200; i++ {
+			replica := replicas[random.Intn(len(replicas))]
+			key := random.Intn(10)
+			switch random.Intn(4) {
+			case 0:
+				replica.Delete(key)
+			case 1:
+				replica.Merge(replicas[random.Intn(len(replicas))])
+			default:
+				replica.Add(key, random.Intn(100))
+			}
+		}
+		var expected *gomap.Map[int, int]
+		for _, order := range [][]int{{0, 1, 2}, {2, 1, 0}, {1, 0, 2}, {1, 2, 0, 1, 2}} {
+			merged := replicas[order[0]].Clone()
+			for _, i := range order[1:] {
+				merged.Merge(replicas[i])
+			}
+			merged.Merge(merged.Clone()) // idempotent
+			if expected == nil {
+				expected = merged.Map()
+			} else if !reflect.DeepEqual(*merged.Map(), *expected) {
+				t.Fatalf("Seed %d: expected merge order %v to produce %v, but got %v", seed, order, expected, merged.Map())
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package gomap
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// parallelChunksPerWorker is the number of chunks created for each worker, so that uneven work is balanced between workers.
+const parallelChunksPerWorker = 4
+
+// entries returns the key-value pairs of the map as a slice so that they can be partitioned into chunks.
+func (gomap *Map[K, V]) entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, len(*gomap))
+	for key, value := range *gomap {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+	}
+	return entries
+}
+
+// parallelChunks partitions the range [0, n) into chunks and calls fn for each chunk on at most limit goroutines.
+// A limit less than 1 uses runtime.GOMAXPROCS(0) goroutines. The context passed to fn is cancelled when fn returns
+// an error or the parent context is done, and the first error is returned.
+func parallelChunks(ctx context.Context, n int, limit int, fn func(ctx context.Context, start int, end int) error) error {
+	if limit < 1 {
+		limit = runtime.GOMAXPROCS(0)
+	}
+	if err := ctx.Err(); err != nil || n == 0 {
+		return err
+	}
+	size := max(1, n/(limit*parallelChunksPerWorker))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var once sync.Once
+	var firstErr error
+	var wg sync.WaitGroup
+	chunks := make(chan int)
+	for i := 0; i < min(limit, (n+size-1)/size); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range chunks {
+				if err := fn(ctx, start, min(start+size, n)); err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+	dispatched := 0
+	for dispatched < n {
+		select {
+		case chunks <- dispatched:
+			dispatched += size
+			continue
+		case <-ctx.Done():
+		}
+		break
+	}
+	close(chunks)
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	if dispatched < n {
+		return ctx.Err()
+	}
+	return nil
+}
+
+// ParallelReduce reduces the key-value pairs of the map on at most limit goroutines. The map is partitioned into chunks,
+// each chunk is reduced with fn starting from initial, and the results of the chunks are combined with combine.
+// Because chunks are reduced independently, initial must be an identity for combine, and combine must be associative
+// and commutative. A limit less than 1 uses runtime.GOMAXPROCS(0) goroutines.
+// Reduction stops at the first error returned by fn, or when the context is done, and that error is returned.
+//
+//	// Create a new Map instance.
+//	newMap := &gomap.Map[string, int]{"apple": 5, "banana": 3, "cherry": 8}
+//	sum, err := gomap.ParallelReduce(ctx, newMap, 4, 0, func(ctx context.Context, sum int, key string, value int) (int, error) {
+//		return sum + value, nil
+//	}, func(a int, b int) int {
+//		return a + b
+//	}) // 16, nil
+func ParallelReduce[K comparable, V any, R any](ctx context.Context, gomap *Map[K, V], limit int, initial R, fn func(ctx context.Context, accumulator R, key K, value V) (R, error), combine func(a R, b R) R) (R, error) {
+	entries := gomap.entries()
+	var mutex sync.Mutex
+	result := initial
+	err := parallelChunks(ctx, len(entries), limit, func(ctx context.Context, start int, end int) error {
+		accumulator := initial
+		for _, entry := range entries[start:end] {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var err error
+			if accumulator, err = fn(ctx, accumulator, entry.Key, entry.Value); err != nil {
+				return err
+			}
+		}
+		mutex.Lock()
+		result = combine(result, accumulator)
+		mutex.Unlock()
+		return nil
+	})
+	if err != nil {
+		var zero R
+		return zero, err
+	}
+	return result, nil
+}
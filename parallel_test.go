@@ -0,0 +1,160 @@
+package gomap_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// newParallelMap returns a map of n integers to their squares.
+func newParallelMap(n int) *gomap.Map[int, int] {
+	newMap := &gomap.Map[int, int]{}
+	for i := 0; i < n; i++ {
+		newMap.Add(i, i*i)
+	}
+	return newMap
+}
+
+// TestParallelEach tests Map.ParallelEach.
+func TestParallelEach(t *testing.T) {
+	newMap := newParallelMap(1000)
+	var mutex sync.Mutex
+	visited := &gomap.Map[int, int]{}
+	var running, peak int32
+	err := newMap.ParallelEach(context.Background(), 4, func(ctx context.Context, key int, value int) error {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			previous := atomic.LoadInt32(&peak)
+			if current <= previous || atomic.CompareAndSwapInt32(&peak, previous, current) {
+				break
+			}
+		}
+		mutex.Lock()
+		visited.Add(key, value)
+		mutex.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if !visited.Equal(newMap) {
+		t.Errorf("Expected every key-value pair to be visited once")
+	}
+	if peak > 4 {
+		t.Errorf("Expected at most 4 concurrent calls, but got %d", peak)
+	}
+
+	// An empty map does not call the function.
+	if err := (&gomap.Map[int, int]{}).ParallelEach(context.Background(), 0, nil); err != nil {
+		t.Errorf("Expected no error for an empty map, but got %v", err)
+	}
+}
+
+// TestParallelEachError tests that Map.ParallelEach stops at the first error.
+func TestParallelEachError(t *testing.T) {
+	newMap := newParallelMap(10000)
+	failure := errors.New("failure")
+	var calls int32
+	err := newMap.ParallelEach(context.Background(), 4, func(ctx context.Context, key int, value int) error {
+		atomic.AddInt32(&calls, 1)
+		return failure
+	})
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected the error returned by the function, but got %v", err)
+	}
+	if calls == int32(newMap.Length()) {
+		t.Errorf("Expected processing to stop early")
+	}
+}
+
+// TestParallelEachContext tests that Map.ParallelEach stops when the context is done.
+func TestParallelEachContext(t *testing.T) {
+	newMap := newParallelMap(1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	err := newMap.ParallelEach(ctx, 2, func(ctx context.Context, key int, value int) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, but got %v", err)
+	}
+	if err := newMap.ParallelEach(ctx, 2, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled for a done context, but got %v", err)
+	}
+}
+
+// TestParallelMap tests Map.ParallelMap.
+func TestParallelMap(t *testing.T) {
+	newMap := newParallelMap(1000)
+	mapped, err := newMap.ParallelMap(context.Background(), 0, func(ctx context.Context, key int, value int) (int, error) {
+		return value + key, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	expected := newMap.Map(func(key int, value int) int {
+		return value + key
+	})
+	if !mapped.Equal(expected) {
+		t.Errorf("Expected ParallelMap to match Map")
+	}
+	if newMap.Fetch(2) != 4 {
+		t.Errorf("Expected the original map to be unchanged")
+	}
+
+	failure := errors.New("failure")
+	if _, err := newMap.ParallelMap(context.Background(), 4, func(ctx context.Context, key int, value int) (int, error) {
+		return 0, failure
+	}); !errors.Is(err, failure) {
+		t.Errorf("Expected the error returned by the function, but got %v", err)
+	}
+}
+
+// TestParallelFilter tests Map.ParallelFilter.
+func TestParallelFilter(t *testing.T) {
+	newMap := newParallelMap(1000)
+	filtered, err := newMap.ParallelFilter(context.Background(), 8, func(ctx context.Context, key int, value int) (bool, error) {
+		return key%3 == 0, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	expected := newMap.Filter(func(key int, value int) bool {
+		return key%3 == 0
+	})
+	if !filtered.Equal(expected) {
+		t.Errorf("Expected ParallelFilter to match Filter")
+	}
+}
+
+// TestParallelReduce tests ParallelReduce.
+func TestParallelReduce(t *testing.T) {
+	newMap := newParallelMap(1000)
+	sum, err := gomap.ParallelReduce(context.Background(), newMap, 4, 0, func(ctx context.Context, sum int, key int, value int) (int, error) {
+		return sum + value, nil
+	}, func(a int, b int) int {
+		return a + b
+	})
+	expected := 0
+	newMap.EachValue(func(value int) {
+		expected += value
+	})
+	if err != nil || sum != expected {
+		t.Errorf("Expected %d, but got %d, %v", expected, sum, err)
+	}
+
+	failure := errors.New("failure")
+	sum, err = gomap.ParallelReduce(context.Background(), newMap, 4, 0, func(ctx context.Context, sum int, key int, value int) (int, error) {
+		return 0, failure
+	}, func(a int, b int) int {
+		return a + b
+	})
+	if !errors.Is(err, failure) || sum != 0 {
+		t.Errorf("Expected the error returned by the function, but got %d, %v", sum, err)
+	}
+}
@@ -0,0 +1,281 @@
+package gomap
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PathSeparator separates the keys of a path into nested maps.
+const PathSeparator = "."
+
+var (
+	// ErrPathNotFound is returned when no value exists at a path.
+	ErrPathNotFound = errors.New("gomap: path not found")
+	// ErrPathType is returned when the value at a path, or a map along it, has an unexpected type.
+	ErrPathType = errors.New("gomap: unexpected type")
+)
+
+// PathError records an error and the operation and path that caused it.
+type PathError struct {
+	Op   string
+	Path string
+	Err  error
+}
+
+// Error returns the operation, path and cause of the error.
+func (err *PathError) Error() string {
+	return err.Op + " " + err.Path + ": " + err.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *PathError) Unwrap() error {
+	return err.Err
+}
+
+// asMap returns the value as a Map if it is a Map[string, any], *Map[string, any] or map[string]any.
+// The returned Map shares its storage with the value.
+func asMap(value any) (*Map[string, any], bool) {
+	switch v := value.(type) {
+	case Map[string, any]:
+		return &v, v != nil
+	case *Map[string, any]:
+		return v, v != nil && *v != nil
+	case map[string]any:
+		newMap := Map[string, any](v)
+		return &newMap, v != nil
+	}
+	return nil, false
+}
+
+// typeError returns an error describing a value that is not of the expected type.
+func typeError(expected string, value any) error {
+	return fmt.Errorf("%w: expected %s, got %T", ErrPathType, expected, value)
+}
+
+// DeletePath removes the value at the dot-separated path and reports whether it was present.
+//
+//	// Create a new Map instance.
+//	config := &gomap.Map[string, any]{"server": map[string]any{"port": 8080}}
+//	ok := gomap.DeletePath(config, "server.port") // true
+func DeletePath(gomap *Map[string, any], path string) bool {
+	keys := strings.Split(path, PathSeparator)
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := asMap(gomap.Fetch(key))
+		if !ok {
+			return false
+		}
+		gomap = next
+	}
+	_, ok := gomap.PopOK(keys[len(keys)-1])
+	return ok
+}
+
+// Flatten returns a new single-level map whose keys are the dot-separated paths to the values of the nested maps.
+// Empty nested maps are kept as values so that Unflatten can restore them.
+//
+//	// Create a new Map instance.
+//	config := &gomap.Map[string, any]{"server": map[string]any{"tls": map[string]any{"cert": "a.pem"}}}
+//	flat := gomap.Flatten(config) // &map[server.tls.cert:a.pem]
+func Flatten(gomap *Map[string, any]) *Map[string, any] {
+	flat := make(Map[string, any])
+	flatten(&flat, "", gomap)
+	return &flat
+}
+
+// flatten adds the values of the map to flat, prefixing their keys with the prefix.
+func flatten(flat *Map[string, any], prefix string, gomap *Map[string, any]) {
+	gomap.Each(func(key string, value any) {
+		if prefix != "" {
+			key = prefix + PathSeparator + key
+		}
+		if next, ok := asMap(value); ok && next.IsPopulated() {
+			flatten(flat, key, next)
+		} else {
+			flat.Add(key, value)
+		}
+	})
+}
+
+// GetPath retrieves the value at the dot-separated path through nested maps.
+// Nested maps may be of type Map[string, any], *Map[string, any] or map[string]any.
+//
+//	// Create a new Map instance.
+//	config := &gomap.Map[string, any]{"server": map[string]any{"tls": map[string]any{"cert": "a.pem"}}}
+//	value, ok := gomap.GetPath(config, "server.tls.cert") // "a.pem", true
+func GetPath(gomap *Map[string, any], path string) (any, bool) {
+	value, err := getPath(gomap, path)
+	return value, err == nil
+}
+
+// getPath retrieves the value at the path or describes why it could not be reached.
+func getPath(gomap *Map[string, any], path string) (any, error) {
+	keys := strings.Split(path, PathSeparator)
+	for i, key := range keys {
+		value, ok := gomap.Get(key)
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		if i == len(keys)-1 {
+			return value, nil
+		}
+		if gomap, ok = asMap(value); !ok {
+			return nil, fmt.Errorf("%w at %s", typeError("map", value), strings.Join(keys[:i+1], PathSeparator))
+		}
+	}
+	return nil, ErrPathNotFound
+}
+
+// GetBool retrieves the bool at the dot-separated path.
+//
+//	// Create a new Map instance.
+//	config := &gomap.Map[string, any]{"debug": true}
+//	debug, err := gomap.GetBool(config, "debug") // true, nil
+func GetBool(gomap *Map[string, any], path string) (bool, error) {
+	value, err := getPath(gomap, path)
+	if err != nil {
+		return false, &PathError{Op: "GetBool", Path: path, Err: err}
+	}
+	if v, ok := value.(bool); ok {
+		return v, nil
+	}
+	return false, &PathError{Op: "GetBool", Path: path, Err: typeError("bool", value)}
+}
+
+// GetDuration retrieves the time.Duration at the dot-separated path.
+// The value may be a time.Duration or a string accepted by time.ParseDuration.
+//
+//	// Create a new Map instance.
+//	config := &gomap.Map[string, any]{"server": map[string]any{"timeout": "1m30s"}}
+//	timeout, err := gomap.GetDuration(config, "server.timeout") // 90s, nil
+func GetDuration(gomap *Map[string, any], path string) (time.Duration, error) {
+	value, err := getPath(gomap, path)
+	if err != nil {
+		return 0, &PathError{Op: "GetDuration", Path: path, Err: err}
+	}
+	switch v := value.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		duration, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, &PathError{Op: "GetDuration", Path: path, Err: fmt.Errorf("%w: %v", ErrPathType, err)}
+		}
+		return duration, nil
+	}
+	return 0, &PathError{Op: "GetDuration", Path: path, Err: typeError("duration", value)}
+}
+
+// GetFloat retrieves the number at the dot-separated path as a float64.
+// The value may be of any integer or floating point type.
+//
+//	// Create a new Map instance.
+//	config := &gomap.Map[string, any]{"ratio": 0.5}
+//	ratio, err := gomap.GetFloat(config, "ratio") // 0.5, nil
+func GetFloat(gomap *Map[string, any], path string) (float64, error) {
+	value, err := getPath(gomap, path)
+	if err != nil {
+		return 0, &PathError{Op: "GetFloat", Path: path, Err: err}
+	}
+	v := reflect.ValueOf(value)
+	switch {
+	case v.CanFloat():
+		return v.Float(), nil
+	case v.CanInt():
+		return float64(v.Int()), nil
+	case v.CanUint():
+		return float64(v.Uint()), nil
+	}
+	return 0, &PathError{Op: "GetFloat", Path: path, Err: typeError("float", value)}
+}
+
+// GetInt retrieves the integer at the dot-separated path.
+// The value may be of any integer type, or a floating point number without a fractional part as produced by encoding/json.
+//
+//	// Create a new Map instance.
+//	config := &gomap.Map[string, any]{"server": map[string]any{"port": 8080}}
+//	port, err := gomap.GetInt(config, "server.port") // 8080, nil
+func GetInt(gomap *Map[string, any], path string) (int, error) {
+	value, err := getPath(gomap, path)
+	if err != nil {
+		return 0, &PathError{Op: "GetInt", Path: path, Err: err}
+	}
+	v := reflect.ValueOf(value)
+	switch {
+	case v.CanInt() && v.Int() >= math.MinInt && v.Int() <= math.MaxInt:
+		return int(v.Int()), nil
+	case v.CanUint() && v.Uint() <= math.MaxInt:
+		return int(v.Uint()), nil
+	case v.CanFloat() && v.Float() == math.Trunc(v.Float()) && v.Float() >= math.MinInt && v.Float() < math.MaxInt:
+		return int(v.Float()), nil
+	}
+	return 0, &PathError{Op: "GetInt", Path: path, Err: typeError("int", value)}
+}
+
+// GetString retrieves the string at the dot-separated path.
+//
+//	// Create a new Map instance.
+//	config := &gomap.Map[string, any]{"server": map[string]any{"tls": map[string]any{"cert": "a.pem"}}}
+//	cert, err := gomap.GetString(config, "server.tls.cert") // "a.pem", nil
+//	_, err = gomap.GetString(config, "server.tls")         // GetString server.tls: gomap: unexpected type: expected string, got map[string]interface {}
+func GetString(gomap *Map[string, any], path string) (string, error) {
+	value, err := getPath(gomap, path)
+	if err != nil {
+		return "", &PathError{Op: "GetString", Path: path, Err: err}
+	}
+	if v, ok := value.(string); ok {
+		return v, nil
+	}
+	return "", &PathError{Op: "GetString", Path: path, Err: typeError("string", value)}
+}
+
+// SetPath stores the value at the dot-separated path, creating intermediate maps of type Map[string, any] as needed.
+// It returns an error if a value along the path exists but is not a map.
+//
+//	// Create a new Map instance.
+//	config := &gomap.Map[string, any]{}
+//	err := gomap.SetPath(config, "server.tls.cert", "a.pem") // &map[server:map[tls:map[cert:a.pem]]]
+func SetPath(gomap *Map[string, any], path string, value any) error {
+	keys := strings.Split(path, PathSeparator)
+	for i, key := range keys[:len(keys)-1] {
+		current, ok := gomap.Get(key)
+		if !ok {
+			next := make(Map[string, any])
+			gomap.Add(key, next)
+			gomap = &next
+			continue
+		}
+		if gomap, ok = asMap(current); !ok {
+			err := fmt.Errorf("%w at %s", typeError("map", current), strings.Join(keys[:i+1], PathSeparator))
+			return &PathError{Op: "SetPath", Path: path, Err: err}
+		}
+	}
+	gomap.Add(keys[len(keys)-1], value)
+	return nil
+}
+
+// Unflatten returns a new nested map built from a single-level map whose keys are dot-separated paths.
+// It returns an error if a path is both a value and a prefix of another path.
+//
+//	// Create a new Map instance.
+//	flat := &gomap.Map[string, any]{"server.tls.cert": "a.pem", "server.port": 8080}
+//	config, err := gomap.Unflatten(flat) // &map[server:map[port:8080 tls:map[cert:a.pem]]]
+func Unflatten(gomap *Map[string, any]) (*Map[string, any], error) {
+	nested := make(Map[string, any])
+	paths := []string(*gomap.Keys())
+	sort.Strings(paths) // Parents sort before their children, so conflicts surface in SetPath.
+	for _, path := range paths {
+		value := gomap.Fetch(path)
+		if v, ok := asMap(value); ok && v.IsEmpty() {
+			value = make(Map[string, any])
+		}
+		if err := SetPath(&nested, path, value); err != nil {
+			return nil, &PathError{Op: "Unflatten", Path: path, Err: errors.Unwrap(err)}
+		}
+	}
+	return &nested, nil
+}
@@ -0,0 +1,163 @@
+package gomap_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// newConfig returns a nested configuration map using each supported nested map type.
+func newConfig() *gomap.Map[string, any] {
+	return &gomap.Map[string, any]{
+		"name": "api",
+		"server": map[string]any{
+			"port":    8080.0,
+			"timeout": "1m30s",
+			"tls": gomap.Map[string, any]{
+				"cert":    "a.pem",
+				"enabled": true}},
+		"limits": &gomap.Map[string, any]{
+			"ratio": 0.5}}
+}
+
+// TestGetPath tests GetPath.
+func TestGetPath(t *testing.T) {
+	config := newConfig()
+	tests := []struct {
+		path     string
+		expected any
+		ok       bool
+	}{
+		{"name", "api", true},
+		{"server.tls.cert", "a.pem", true},
+		{"limits.ratio", 0.5, true},
+		{"server.missing", nil, false},
+		{"name.first", nil, false},
+	}
+	for _, test := range tests {
+		value, ok := gomap.GetPath(config, test.path)
+		if ok != test.ok || !reflect.DeepEqual(value, test.expected) {
+			t.Errorf("GetPath(%q): expected %v, %t, but got %v, %t", test.path, test.expected, test.ok, value, ok)
+		}
+	}
+}
+
+// TestSetPath tests SetPath.
+func TestSetPath(t *testing.T) {
+	config := newConfig()
+
+	// Test case 1: Intermediate maps are created.
+	if err := gomap.SetPath(config, "database.primary.host", "localhost"); err != nil {
+		t.Fatalf("Expected SetPath to succeed, but got %v", err)
+	}
+	if value, _ := gomap.GetString(config, "database.primary.host"); value != "localhost" {
+		t.Errorf("Expected 'localhost', but got %q", value)
+	}
+
+	// Test case 2: Existing nested maps of every type are written through.
+	for _, path := range []string{"server.tls.key", "server.host", "limits.burst"} {
+		if err := gomap.SetPath(config, path, 1); err != nil {
+			t.Errorf("Expected SetPath(%q) to succeed, but got %v", path, err)
+		}
+		if value, ok := gomap.GetPath(config, path); !ok || value != 1 {
+			t.Errorf("Expected %q to be set, but got %v", path, value)
+		}
+	}
+
+	// Test case 3: Values along the path must be maps.
+	err := gomap.SetPath(config, "name.first", "a")
+	if !errors.Is(err, gomap.ErrPathType) {
+		t.Errorf("Expected ErrPathType, but got %v", err)
+	}
+}
+
+// TestDeletePath tests DeletePath.
+func TestDeletePath(t *testing.T) {
+	config := newConfig()
+	if !gomap.DeletePath(config, "server.tls.cert") {
+		t.Errorf("Expected DeletePath to report the value was present")
+	}
+	if _, ok := gomap.GetPath(config, "server.tls.cert"); ok {
+		t.Errorf("Expected 'server.tls.cert' to be deleted")
+	}
+	if gomap.DeletePath(config, "server.tls.cert") || gomap.DeletePath(config, "name.first") {
+		t.Errorf("Expected DeletePath to report missing values")
+	}
+}
+
+// TestGetTyped tests GetString, GetInt, GetFloat, GetBool and GetDuration.
+func TestGetTyped(t *testing.T) {
+	config := newConfig()
+	if value, err := gomap.GetString(config, "server.tls.cert"); err != nil || value != "a.pem" {
+		t.Errorf("Expected 'a.pem', but got %q, %v", value, err)
+	}
+	if value, err := gomap.GetInt(config, "server.port"); err != nil || value != 8080 {
+		t.Errorf("Expected 8080, but got %d, %v", value, err)
+	}
+	if value, err := gomap.GetFloat(config, "limits.ratio"); err != nil || value != 0.5 {
+		t.Errorf("Expected 0.5, but got %v, %v", value, err)
+	}
+	if value, err := gomap.GetBool(config, "server.tls.enabled"); err != nil || !value {
+		t.Errorf("Expected true, but got %v, %v", value, err)
+	}
+	if value, err := gomap.GetDuration(config, "server.timeout"); err != nil || value != 90*time.Second {
+		t.Errorf("Expected 1m30s, but got %v, %v", value, err)
+	}
+
+	// Type mismatches return descriptive errors.
+	_, err := gomap.GetInt(config, "limits.ratio")
+	if !errors.Is(err, gomap.ErrPathType) {
+		t.Errorf("Expected ErrPathType, but got %v", err)
+	}
+	expected := "GetInt limits.ratio: gomap: unexpected type: expected int, got float64"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q, but got %v", expected, err)
+	}
+	var pathError *gomap.PathError
+	if _, err := gomap.GetString(config, "server.missing"); !errors.As(err, &pathError) || !errors.Is(err, gomap.ErrPathNotFound) {
+		t.Errorf("Expected a PathError wrapping ErrPathNotFound, but got %v", err)
+	}
+	if _, err := gomap.GetDuration(config, "name"); !errors.Is(err, gomap.ErrPathType) {
+		t.Errorf("Expected ErrPathType for an invalid duration, but got %v", err)
+	}
+}
+
+// TestFlatten tests Flatten and Unflatten.
+func TestFlatten(t *testing.T) {
+	config := &gomap.Map[string, any]{
+		"name":   "api",
+		"empty":  map[string]any{},
+		"server": map[string]any{"port": 8080, "tls": map[string]any{"cert": "a.pem"}}}
+
+	flat := gomap.Flatten(config)
+	expected := &gomap.Map[string, any]{
+		"name":            "api",
+		"empty":           map[string]any{},
+		"server.port":     8080,
+		"server.tls.cert": "a.pem"}
+	if !flat.Equal(expected) {
+		t.Errorf("Expected %v, but got %v", expected, flat)
+	}
+
+	nested, err := gomap.Unflatten(flat)
+	if err != nil {
+		t.Fatalf("Expected Unflatten to succeed, but got %v", err)
+	}
+	if !gomap.Flatten(nested).EqualFunc(flat, func(a, b any) bool {
+		return reflect.DeepEqual(a, b) || reflect.ValueOf(a).Len() == 0 && reflect.ValueOf(b).Len() == 0
+	}) {
+		t.Errorf("Expected Flatten(Unflatten(flat)) to equal flat, but got %v", gomap.Flatten(nested))
+	}
+	if value, _ := gomap.GetString(nested, "server.tls.cert"); value != "a.pem" {
+		t.Errorf("Expected 'a.pem', but got %q", value)
+	}
+
+	// A path cannot be both a value and a map.
+	_, err = gomap.Unflatten(&gomap.Map[string, any]{"server": 1, "server.port": 8080})
+	if !errors.Is(err, gomap.ErrPathType) {
+		t.Errorf("Expected ErrPathType, but got %v", err)
+	}
+}
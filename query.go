@@ -0,0 +1,590 @@
+package gomap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrQuerySyntax is returned by CompileQuery when a query cannot be parsed.
+var ErrQuerySyntax = errors.New("gomap: query syntax error")
+
+// QueryError records a query that could not be parsed and the column, a 1-based byte offset, at which parsing failed.
+type QueryError struct {
+	Query   string
+	Column  int
+	Message string
+}
+
+// Error describes the problem, the column at which it occurred and the query.
+func (err *QueryError) Error() string {
+	return fmt.Sprintf("%s: %s at column %d in %q", ErrQuerySyntax, err.Message, err.Column, err.Query)
+}
+
+// Unwrap returns ErrQuerySyntax.
+func (err *QueryError) Unwrap() error {
+	return ErrQuerySyntax
+}
+
+// queryCacheSize limits the number of compiled queries kept by CompileQuery.
+const queryCacheSize = 256
+
+// queryCache holds compiled queries by their source.
+var queryCache = struct {
+	sync.Mutex
+	queries map[string]*Query
+}{queries: make(map[string]*Query)}
+
+// Query is a compiled boolean expression that is matched against values.
+//
+// A query compares fields of the value with literals or other fields, and combines comparisons with
+// && (and), || (or), ! (not) and parentheses. The comparison operators are ==, !=, <, <=, >, >=, contains,
+// startsWith and endsWith. Literals are double-quoted strings, numbers, true, false and null.
+// A field on its own, such as active, matches if it is true.
+//
+// Fields are resolved on structs using the name in their query tag, then their json tag, then the field name,
+// and on maps with string keys using the key. Nested fields are separated by dots, as in address.city.
+// Fields that do not exist resolve to null. Integers, floats and json.Number values are compared as numbers.
+//
+// A Query is safe for concurrent use.
+type Query struct {
+	expr   queryExpr
+	source string
+}
+
+// CompileQuery parses the query and returns a Query that can be reused. Compiled queries are cached,
+// so compiling the same query again is cheap. It returns a *QueryError if the query cannot be parsed.
+//
+//	// Compile a new Query.
+//	query, err := gomap.CompileQuery(`age > 30 && name startsWith "A"`)
+//	people := &gomap.Map[string, Person]{"ada": {Name: "Ada", Age: 36}, "bob": {Name: "Bob", Age: 42}}
+//	matches := people.Filter(gomap.QueryFunc[string, Person](query)) // &map[ada:{Ada 36}]
+func CompileQuery(source string) (*Query, error) {
+	queryCache.Lock()
+	query, ok := queryCache.queries[source]
+	queryCache.Unlock()
+	if ok {
+		return query, nil
+	}
+	parser := &queryParser{source: source}
+	if err := parser.lex(); err != nil {
+		return nil, err
+	}
+	expr, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if token := parser.peek(); token.kind != queryEOF {
+		return nil, parser.errorf(token, "unexpected %s", token)
+	}
+	query = &Query{expr: expr, source: source}
+	queryCache.Lock()
+	if len(queryCache.queries) >= queryCacheSize {
+		for key := range queryCache.queries {
+			delete(queryCache.queries, key)
+			break
+		}
+	}
+	queryCache.queries[source] = query
+	queryCache.Unlock()
+	return query, nil
+}
+
+// MustCompileQuery is like CompileQuery but panics if the query cannot be parsed.
+//
+//	// Compile a new Query.
+//	var adults = gomap.MustCompileQuery("age >= 18")
+func MustCompileQuery(source string) *Query {
+	query, err := CompileQuery(source)
+	if err != nil {
+		panic(err)
+	}
+	return query
+}
+
+// QueryFunc returns a function that matches the values of a map against the query,
+// for use with Filter, DeleteManyFunc, PopManyFunc and ValuesFunc.
+//
+//	// Create a new Map instance.
+//	people := &gomap.Map[string, Person]{"ada": {Name: "Ada", Age: 36}, "bob": {Name: "Bob", Age: 17}}
+//	people.DeleteManyFunc(gomap.QueryFunc[string, Person](gomap.MustCompileQuery("age < 18"))) // &map[ada:{Ada 36}]
+func QueryFunc[K comparable, V any](query *Query) func(key K, value V) bool {
+	return func(key K, value V) bool {
+		return query.Match(value)
+	}
+}
+
+// QueryKeyFunc returns a function that matches the keys of a map against the query, for use with KeysFunc.
+//
+//	// Create a new Map instance.
+//	hits := &gomap.Map[Route, int]{{Method: "GET", Path: "/api"}: 10, {Method: "POST", Path: "/api"}: 2}
+//	keys := hits.KeysFunc(gomap.QueryKeyFunc[Route](gomap.MustCompileQuery(`method == "GET"`))) // &[{GET /api}]
+func QueryKeyFunc[K comparable](query *Query) func(key K) bool {
+	return func(key K) bool {
+		return query.Match(key)
+	}
+}
+
+// Match reports whether the value satisfies the query.
+func (query *Query) Match(value any) bool {
+	return query.expr.match(reflect.ValueOf(value))
+}
+
+// String returns the source of the query.
+func (query *Query) String() string {
+	return query.source
+}
+
+// queryExpr is a node of a query that evaluates to a bool.
+type queryExpr interface {
+	match(value reflect.Value) bool
+}
+
+// queryOperand is a node of a query that evaluates to a field or literal.
+type queryOperand interface {
+	resolve(value reflect.Value) any
+}
+
+type (
+	queryAnd struct{ left, right queryExpr }
+	queryOr  struct{ left, right queryExpr }
+	queryNot struct{ expr queryExpr }
+	// queryCompare compares two operands using one of the comparison operators.
+	queryCompare struct {
+		left, right queryOperand
+		op          string
+	}
+	// queryTruthy matches if the operand is true.
+	queryTruthy  struct{ operand queryOperand }
+	queryLiteral struct{ value any }
+	queryField   struct{ path []string }
+)
+
+func (expr queryAnd) match(value reflect.Value) bool {
+	return expr.left.match(value) && expr.right.match(value)
+}
+
+func (expr queryOr) match(value reflect.Value) bool {
+	return expr.left.match(value) || expr.right.match(value)
+}
+
+func (expr queryNot) match(value reflect.Value) bool {
+	return !expr.expr.match(value)
+}
+
+func (expr queryTruthy) match(value reflect.Value) bool {
+	b, ok := expr.operand.resolve(value).(bool)
+	return ok && b
+}
+
+func (expr queryCompare) match(value reflect.Value) bool {
+	left, right := expr.left.resolve(value), expr.right.resolve(value)
+	switch expr.op {
+	case "==":
+		return queryEqual(left, right)
+	case "!=":
+		return !queryEqual(left, right)
+	case "<", "<=", ">", ">=":
+		order, ok := queryOrder(left, right)
+		if !ok {
+			return false
+		}
+		switch expr.op {
+		case "<":
+			return order < 0
+		case "<=":
+			return order <= 0
+		case ">":
+			return order > 0
+		}
+		return order >= 0
+	case "contains":
+		if s, ok := left.(string); ok {
+			substr, ok := right.(string)
+			return ok && strings.Contains(s, substr)
+		}
+		if elements, ok := left.([]any); ok {
+			for _, element := range elements {
+				if queryEqual(element, right) {
+					return true
+				}
+			}
+		}
+		return false
+	case "startsWith", "endsWith":
+		s, ok := left.(string)
+		affix, affixOK := right.(string)
+		if !ok || !affixOK {
+			return false
+		}
+		if expr.op == "startsWith" {
+			return strings.HasPrefix(s, affix)
+		}
+		return strings.HasSuffix(s, affix)
+	}
+	return false
+}
+
+func (operand queryLiteral) resolve(value reflect.Value) any {
+	return operand.value
+}
+
+func (operand queryField) resolve(value reflect.Value) any {
+	for _, name := range operand.path {
+		value = queryIndirect(value)
+		switch value.Kind() {
+		case reflect.Struct:
+			index, ok := queryFieldIndex(value.Type(), name)
+			if !ok {
+				return nil
+			}
+			field, err := value.FieldByIndexErr(index)
+			if err != nil {
+				return nil
+			}
+			value = field
+		case reflect.Map:
+			if value.Type().Key().Kind() != reflect.String {
+				return nil
+			}
+			value = value.MapIndex(reflect.ValueOf(name).Convert(value.Type().Key()))
+		default:
+			return nil
+		}
+	}
+	return queryNormalize(value)
+}
+
+// queryIndirect follows pointers and interfaces to the value they hold.
+func queryIndirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	return value
+}
+
+// queryFieldKey identifies the field of a struct type resolved for a query field name.
+type queryFieldKey struct {
+	name string
+	t    reflect.Type
+}
+
+// queryFields caches the field indexes resolved by queryFieldIndex.
+var queryFields sync.Map
+
+// queryFieldIndex finds the exported field of the struct type named by its query tag, json tag or name.
+func queryFieldIndex(t reflect.Type, name string) ([]int, bool) {
+	key := queryFieldKey{name: name, t: t}
+	if index, ok := queryFields.Load(key); ok {
+		return index.([]int), index.([]int) != nil
+	}
+	var index []int
+	fields := reflect.VisibleFields(t)
+	for _, lookup := range []func(field reflect.StructField) string{
+		func(field reflect.StructField) string { return strings.Split(field.Tag.Get("query"), ",")[0] },
+		func(field reflect.StructField) string { return strings.Split(field.Tag.Get("json"), ",")[0] },
+		func(field reflect.StructField) string { return field.Name },
+	} {
+		for _, field := range fields {
+			if field.IsExported() && !field.Anonymous && lookup(field) == name {
+				index = field.Index
+				break
+			}
+		}
+		if index != nil {
+			break
+		}
+	}
+	queryFields.Store(key, index)
+	return index, index != nil
+}
+
+// queryNumberType is the type of json.Number, which holds numbers decoded by a json.Decoder that uses UseNumber.
+var queryNumberType = reflect.TypeOf(json.Number(""))
+
+// queryNormalize converts the value to a string, float64, bool, []any or nil where possible,
+// so that values of different types can be compared with literals.
+func queryNormalize(value reflect.Value) any {
+	value = queryIndirect(value)
+	switch {
+	case !value.IsValid():
+		return nil
+	case value.Type() == queryNumberType:
+		if number, err := strconv.ParseFloat(value.String(), 64); err == nil {
+			return number
+		}
+	case value.CanInt():
+		return float64(value.Int())
+	case value.CanUint():
+		return float64(value.Uint())
+	case value.CanFloat():
+		return value.Float()
+	}
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Bool:
+		return value.Bool()
+	case reflect.Slice, reflect.Array:
+		elements := make([]any, value.Len())
+		for i := range elements {
+			elements[i] = queryNormalize(value.Index(i))
+		}
+		return elements
+	}
+	if !value.CanInterface() {
+		return nil
+	}
+	return value.Interface()
+}
+
+// queryEqual checks if two normalized values are equal.
+func queryEqual(a any, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+// queryOrder compares two normalized numbers or strings and reports whether they can be ordered.
+func queryOrder(a any, b any) (int, bool) {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			switch {
+			case a < b:
+				return -1, true
+			case a > b:
+				return 1, true
+			}
+			return 0, a == b
+		}
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	}
+	return 0, false
+}
+
+// queryTokenKind identifies the kind of a token produced by the lexer.
+type queryTokenKind int
+
+const (
+	queryEOF queryTokenKind = iota
+	queryIdent
+	queryNumber
+	queryString
+	queryOperator
+	queryLeftParen
+	queryRightParen
+)
+
+// queryToken is a token of a query and the column at which it starts.
+type queryToken struct {
+	column int
+	kind   queryTokenKind
+	text   string
+}
+
+// String describes the token for error messages.
+func (token queryToken) String() string {
+	if token.kind == queryEOF {
+		return "end of query"
+	}
+	return strconv.Quote(token.text)
+}
+
+// queryComparisons are the comparison operators that are spelled as words.
+var queryComparisons = map[string]bool{"contains": true, "startsWith": true, "endsWith": true}
+
+// queryParser turns the source of a query into tokens and parses them by recursive descent.
+type queryParser struct {
+	position int
+	source   string
+	tokens   []queryToken
+}
+
+// errorf returns a *QueryError at the column of the token.
+func (parser *queryParser) errorf(token queryToken, format string, args ...any) error {
+	return &QueryError{Query: parser.source, Column: token.column, Message: fmt.Sprintf(format, args...)}
+}
+
+// lex splits the source into tokens.
+func (parser *queryParser) lex() error {
+	source := parser.source
+	for i := 0; i < len(source); {
+		c, width := utf8.DecodeRuneInString(source[i:])
+		start := i
+		token := queryToken{column: i + 1}
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '(':
+			token.kind = queryLeftParen
+			i++
+		case c == ')':
+			token.kind = queryRightParen
+			i++
+		case c == '"':
+			for i++; i < len(source) && source[i] != '"'; i++ {
+				if source[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(source) {
+				return parser.errorf(token, "unterminated string")
+			}
+			i++
+			token.kind = queryString
+		case c == '-' || c >= '0' && c <= '9':
+			for i++; i < len(source) && (source[i] >= '0' && source[i] <= '9' || strings.IndexByte(".eE+-", source[i]) >= 0); i++ {
+			}
+			token.kind = queryNumber
+		case c == '_' || unicode.IsLetter(c):
+			for i += width; i < len(source); i += width {
+				c, width = utf8.DecodeRuneInString(source[i:])
+				if c != '_' && c != '.' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+					break
+				}
+			}
+			token.kind = queryIdent
+		default:
+			for _, op := range []string{"&&", "||", "==", "!=", "<=", ">=", "<", ">", "!"} {
+				if strings.HasPrefix(source[i:], op) {
+					token.kind = queryOperator
+					i += len(op)
+					break
+				}
+			}
+			if token.kind != queryOperator {
+				return parser.errorf(token, "unexpected character %q", c)
+			}
+		}
+		token.text = source[start:i]
+		if token.kind == queryIdent && queryComparisons[token.text] {
+			token.kind = queryOperator
+		}
+		parser.tokens = append(parser.tokens, token)
+	}
+	parser.tokens = append(parser.tokens, queryToken{column: len(source) + 1, kind: queryEOF})
+	return nil
+}
+
+// peek returns the next token without consuming it.
+func (parser *queryParser) peek() queryToken {
+	return parser.tokens[parser.position]
+}
+
+// next consumes and returns the next token.
+func (parser *queryParser) next() queryToken {
+	token := parser.tokens[parser.position]
+	if token.kind != queryEOF {
+		parser.position++
+	}
+	return token
+}
+
+// accept consumes the next token if it is the operator.
+func (parser *queryParser) accept(op string) bool {
+	if token := parser.peek(); token.kind == queryOperator && token.text == op {
+		parser.position++
+		return true
+	}
+	return false
+}
+
+// parseOr parses and expressions separated by "||".
+func (parser *queryParser) parseOr() (queryExpr, error) {
+	left, err := parser.parseAnd()
+	for err == nil && parser.accept("||") {
+		var right queryExpr
+		if right, err = parser.parseAnd(); err == nil {
+			left = queryOr{left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+// parseAnd parses unary expressions separated by "&&".
+func (parser *queryParser) parseAnd() (queryExpr, error) {
+	left, err := parser.parseUnary()
+	for err == nil && parser.accept("&&") {
+		var right queryExpr
+		if right, err = parser.parseUnary(); err == nil {
+			left = queryAnd{left: left, right: right}
+		}
+	}
+	return left, err
+}
+
+// parseUnary parses a negation, a parenthesized expression or a comparison.
+func (parser *queryParser) parseUnary() (queryExpr, error) {
+	if parser.accept("!") {
+		expr, err := parser.parseUnary()
+		return queryNot{expr: expr}, err
+	}
+	if parser.peek().kind == queryLeftParen {
+		parser.next()
+		expr, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if token := parser.next(); token.kind != queryRightParen {
+			return nil, parser.errorf(token, "expected \")\" but found %s", token)
+		}
+		return expr, nil
+	}
+	left, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	token := parser.peek()
+	if token.kind != queryOperator || token.text == "&&" || token.text == "||" || token.text == "!" {
+		return queryTruthy{operand: left}, nil
+	}
+	parser.next()
+	right, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return queryCompare{left: left, right: right, op: token.text}, nil
+}
+
+// parseOperand parses a field or literal.
+func (parser *queryParser) parseOperand() (queryOperand, error) {
+	token := parser.next()
+	switch token.kind {
+	case queryString:
+		value, err := strconv.Unquote(token.text)
+		if err != nil {
+			return nil, parser.errorf(token, "invalid string %s", token.text)
+		}
+		return queryLiteral{value: value}, nil
+	case queryNumber:
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, parser.errorf(token, "invalid number %s", token.text)
+		}
+		return queryLiteral{value: value}, nil
+	case queryIdent:
+		switch token.text {
+		case "true", "false":
+			return queryLiteral{value: token.text == "true"}, nil
+		case "null":
+			return queryLiteral{}, nil
+		}
+		path := strings.Split(token.text, ".")
+		for _, name := range path {
+			if name == "" {
+				return nil, parser.errorf(token, "invalid field %s", token.text)
+			}
+		}
+		return queryField{path: path}, nil
+	}
+	return nil, parser.errorf(token, "expected a field or value but found %s", token)
+}
@@ -0,0 +1,176 @@
+package gomap_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// address is a nested value used in the query tests.
+type address struct {
+	City string `json:"city"`
+}
+
+// record is a value used in the query tests.
+type record struct {
+	Name    string `query:"name"`
+	Age     int    `json:"age,omitempty"`
+	Active  bool
+	Tags    []string
+	Address *address `json:"address"`
+}
+
+// newRecords returns a map of records for the query tests.
+func newRecords() *gomap.Map[string, record] {
+	return &gomap.Map[string, record]{
+		"ada":   {Name: "Ada", Age: 36, Active: true, Tags: []string{"admin"}, Address: &address{"London"}},
+		"alan":  {Name: "Alan", Age: 41, Tags: []string{"dev"}, Address: &address{"Wilmslow"}},
+		"bob":   {Name: "Bob", Age: 17, Active: true},
+		"grace": {Name: "Grace", Age: 85, Tags: []string{"admin", "dev"}}}
+}
+
+// TestCompileQuery tests CompileQuery and Query.Match against structs.
+func TestCompileQuery(t *testing.T) {
+	records := newRecords()
+	tests := []struct {
+		query    string
+		expected []string
+	}{
+		{`age > 30 && name startsWith "A"`, []string{"ada", "alan"}},
+		{`age >= 41 || !Active`, []string{"alan", "grace"}},
+		{`Active`, []string{"ada", "bob"}},
+		{`!(Active || age < 50)`, []string{"grace"}},
+		{`Tags contains "admin" && Tags contains "dev"`, []string{"grace"}},
+		{`name contains "ac" || name endsWith "b"`, []string{"bob", "grace"}},
+		{`address.city == "London"`, []string{"ada"}},
+		{`address == null`, []string{"bob", "grace"}},
+		{`missing == null && age != 17`, []string{"ada", "alan", "grace"}},
+		{`Name == "Ada"`, []string{"ada"}},
+		{`name < "B"`, []string{"ada", "alan"}},
+		{`age == -1 || age == 4.1e1`, []string{"alan"}},
+		{`name > 1`, nil},
+	}
+	for _, test := range tests {
+		query, err := gomap.CompileQuery(test.query)
+		if err != nil {
+			t.Errorf("CompileQuery(%q): expected no error, but got %v", test.query, err)
+			continue
+		}
+		keys := []string(*records.KeysFunc(func(key string) bool {
+			return query.Match(records.Fetch(key))
+		}))
+		sort.Strings(keys)
+		if len(keys) != len(test.expected) || len(keys) > 0 && !reflect.DeepEqual(keys, test.expected) {
+			t.Errorf("%q: expected %v, but got %v", test.query, test.expected, keys)
+		}
+	}
+}
+
+// TestCompileQueryError tests the errors returned by CompileQuery.
+func TestCompileQueryError(t *testing.T) {
+	tests := []struct {
+		query  string
+		column int
+	}{
+		{`age > `, 7},
+		{`age > 30 &&`, 12},
+		{`(age > 30`, 10},
+		{`age > 30)`, 9},
+		{`name == "Ada`, 9},
+		{`age # 1`, 5},
+		{`age > 30 name`, 10},
+		{`a..b`, 1},
+	}
+	for _, test := range tests {
+		_, err := gomap.CompileQuery(test.query)
+		var queryError *gomap.QueryError
+		if !errors.As(err, &queryError) || !errors.Is(err, gomap.ErrQuerySyntax) {
+			t.Errorf("CompileQuery(%q): expected a QueryError, but got %v", test.query, err)
+			continue
+		}
+		if queryError.Column != test.column {
+			t.Errorf("CompileQuery(%q): expected column %d, but got %d (%v)", test.query, test.column, queryError.Column, err)
+		}
+	}
+}
+
+// TestCompileQueryUnicode tests that identifiers and strings may contain non-ASCII characters.
+func TestCompileQueryUnicode(t *testing.T) {
+	value := map[string]any{"città": "Zürich", "größe": 180, "名前": "太郎"}
+	for _, query := range []string{`città == "Zürich"`, `größe > 170 && 名前 == "太郎"`, `_città.x == null`} {
+		if !gomap.MustCompileQuery(query).Match(value) {
+			t.Errorf("%q: expected a match", query)
+		}
+	}
+	_, err := gomap.CompileQuery(`größe € 1`)
+	var queryError *gomap.QueryError
+	if !errors.As(err, &queryError) || queryError.Column != 9 {
+		t.Errorf("Expected an error at column 9, but got %v", err)
+	}
+}
+
+// TestCompileQueryCache tests that compiled queries are reused.
+func TestCompileQueryCache(t *testing.T) {
+	a, _ := gomap.CompileQuery(`age > 30`)
+	b, _ := gomap.CompileQuery(`age > 30`)
+	if a != b {
+		t.Errorf("Expected the compiled query to be cached")
+	}
+	if a.String() != `age > 30` {
+		t.Errorf("Expected 'age > 30', but got %q", a.String())
+	}
+}
+
+// TestMustCompileQuery tests MustCompileQuery.
+func TestMustCompileQuery(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected MustCompileQuery to panic")
+		}
+	}()
+	gomap.MustCompileQuery(`age >`)
+}
+
+// TestQueryFunc tests QueryFunc with Filter, ValuesFunc and DeleteManyFunc.
+func TestQueryFunc(t *testing.T) {
+	records := newRecords()
+	adults := gomap.QueryFunc[string, record](gomap.MustCompileQuery(`age >= 18`))
+	if filtered := records.Filter(adults); filtered.Length() != 3 || filtered.Has("bob") {
+		t.Errorf("Expected 3 adults, but got %v", filtered)
+	}
+	if values := records.ValuesFunc(adults); values.Length() != 3 {
+		t.Errorf("Expected 3 values, but got %v", values)
+	}
+	records.DeleteManyFunc(adults)
+	if records.Length() != 1 || !records.Has("bob") {
+		t.Errorf("Expected only 'bob' to remain, but got %v", records)
+	}
+}
+
+// TestQueryKeyFunc tests QueryKeyFunc with KeysFunc.
+func TestQueryKeyFunc(t *testing.T) {
+	hits := &gomap.Map[address, int]{{"London"}: 1, {"Paris"}: 2}
+	keys := hits.KeysFunc(gomap.QueryKeyFunc[address](gomap.MustCompileQuery(`city == "Paris"`)))
+	if keys.Length() != 1 || keys.Fetch(0).City != "Paris" {
+		t.Errorf("Expected [{Paris}], but got %v", keys)
+	}
+}
+
+// TestQueryMap tests queries against values of type map[string]any.
+func TestQueryMap(t *testing.T) {
+	query := gomap.MustCompileQuery(`server.port == 8080 && server.tls && name != "web"`)
+	config := map[string]any{"name": "api", "server": map[string]any{"port": 8080, "tls": true}}
+	if !query.Match(config) {
+		t.Errorf("Expected %v to match %q", config, query)
+	}
+	if query.Match(map[string]any{"name": "api", "server": 1}) {
+		t.Errorf("Expected a missing nested field not to match")
+	}
+	if !gomap.MustCompileQuery(`port > 80 && port < 8080.5`).Match(map[string]any{"port": json.Number("8080")}) {
+		t.Errorf("Expected json.Number values to be compared as numbers")
+	}
+}
@@ -0,0 +1,427 @@
+package gomap
+
+import (
+	"sort"
+
+	"github.com/lindsaygelle/slice"
+)
+
+// trieNode is a node of a radix tree. Each node stores the part of the key on the edge leading to it,
+// and its children are kept sorted by the first byte of their prefix.
+type trieNode[V any] struct {
+	children []*trieNode[V]
+	ok       bool
+	prefix   string
+	value    V
+}
+
+// child returns the index of the child whose prefix starts with the byte, and whether it exists.
+func (node *trieNode[V]) child(b byte) (int, bool) {
+	i := sort.Search(len(node.children), func(i int) bool {
+		return node.children[i].prefix[0] >= b
+	})
+	return i, i < len(node.children) && node.children[i].prefix[0] == b
+}
+
+// each calls fn for each key-value pair below the node in key order until fn returns false.
+func (node *trieNode[V]) each(key []byte, fn func(key string, value V) bool) bool {
+	key = append(key, node.prefix...)
+	if node.ok && !fn(string(key), node.value) {
+		return false
+	}
+	for _, child := range node.children {
+		if !child.each(key, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// length returns the number of key-value pairs below the node.
+func (node *trieNode[V]) length() int {
+	length := 0
+	if node.ok {
+		length++
+	}
+	for _, child := range node.children {
+		length += child.length()
+	}
+	return length
+}
+
+// compact merges a valueless node with its only child.
+func (node *trieNode[V]) compact() {
+	if node.ok || len(node.children) != 1 {
+		return
+	}
+	child := node.children[0]
+	node.children = child.children
+	node.ok = child.ok
+	node.prefix += child.prefix
+	node.value = child.value
+}
+
+// removeChild removes the child at index i.
+func (node *trieNode[V]) removeChild(i int) {
+	copy(node.children[i:], node.children[i+1:])
+	node.children[len(node.children)-1] = nil
+	node.children = node.children[:len(node.children)-1]
+}
+
+// trieHasPrefix checks if the key starts with the prefix without converting the key.
+func trieHasPrefix[S string | []byte](key S, prefix string) bool {
+	if len(key) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if key[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// trieGet finds the node holding exactly the key.
+func trieGet[V any, S string | []byte](node *trieNode[V], key S) (*trieNode[V], bool) {
+	for len(key) > 0 {
+		i, ok := node.child(key[0])
+		if !ok || !trieHasPrefix(key, node.children[i].prefix) {
+			return nil, false
+		}
+		node = node.children[i]
+		key = key[len(node.prefix):]
+	}
+	return node, node.ok
+}
+
+// TrieMap is a map with string keys backed by a radix tree. In addition to the usual map operations it supports
+// efficient prefix queries and iterates over its keys in sorted order. Keys may also be looked up as byte slices.
+// The zero value is an empty TrieMap ready to use.
+type TrieMap[V any] struct {
+	length int
+	root   trieNode[V]
+}
+
+// NewTrieMap creates a new TrieMap containing the key-value pairs of the provided maps.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap(map[string]int{"/api/users": 1, "/api/orders": 2, "/health": 3})
+//	keys := trieMap.WithPrefix("/api/").Keys() // {"/api/orders", "/api/users"}
+func NewTrieMap[V any](values ...map[string]V) *TrieMap[V] {
+	trieMap := &TrieMap[V]{}
+	for _, item := range values {
+		for key, value := range item {
+			trieMap.Add(key, value)
+		}
+	}
+	return trieMap
+}
+
+// Add inserts a new key-value pair into the map or updates the existing value associated with the provided key.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap[int]()
+//	trieMap.Add("apple", 5).Add("apricot", 3)
+func (trieMap *TrieMap[V]) Add(key string, value V) *TrieMap[V] {
+	node := &trieMap.root
+	for len(key) > 0 {
+		i, ok := node.child(key[0])
+		if !ok {
+			leaf := &trieNode[V]{ok: true, prefix: key, value: value}
+			node.children = append(node.children, nil)
+			copy(node.children[i+1:], node.children[i:])
+			node.children[i] = leaf
+			trieMap.length++
+			return trieMap
+		}
+		child := node.children[i]
+		common := 0
+		for common < len(key) && common < len(child.prefix) && key[common] == child.prefix[common] {
+			common++
+		}
+		if common < len(child.prefix) {
+			// Split the edge so that the common part of the prefix gets its own node.
+			split := &trieNode[V]{children: []*trieNode[V]{child}, prefix: child.prefix[:common]}
+			child.prefix = child.prefix[common:]
+			node.children[i] = split
+			child = split
+		}
+		node = child
+		key = key[common:]
+	}
+	if !node.ok {
+		trieMap.length++
+	}
+	node.ok = true
+	node.value = value
+	return trieMap
+}
+
+// Delete removes the key-value pair with the provided key from the map.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap(map[string]int{"apple": 5})
+//	trieMap.Delete("apple")
+func (trieMap *TrieMap[V]) Delete(key string) *TrieMap[V] {
+	trieMap.DeleteOK(key)
+	return trieMap
+}
+
+// DeleteOK removes the key-value pair with the provided key from the map and reports whether it was present.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap(map[string]int{"apple": 5})
+//	ok := trieMap.DeleteOK("apple")  // true
+//	ok = trieMap.DeleteOK("apple")   // false
+func (trieMap *TrieMap[V]) DeleteOK(key string) bool {
+	var parent *trieNode[V]
+	node, index := &trieMap.root, 0
+	for len(key) > 0 {
+		i, ok := node.child(key[0])
+		if !ok || !trieHasPrefix(key, node.children[i].prefix) {
+			return false
+		}
+		parent, node, index = node, node.children[i], i
+		key = key[len(node.prefix):]
+	}
+	if !node.ok {
+		return false
+	}
+	var value V
+	node.ok = false
+	node.value = value
+	trieMap.length--
+	trieMap.prune(parent, node, index)
+	return true
+}
+
+// DeletePrefix removes all key-value pairs whose keys start with the prefix and returns the number removed.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap(map[string]int{"/api/users": 1, "/api/orders": 2, "/health": 3})
+//	removed := trieMap.DeletePrefix("/api/") // 2
+func (trieMap *TrieMap[V]) DeletePrefix(prefix string) int {
+	if prefix == "" {
+		removed := trieMap.length
+		trieMap.root = trieNode[V]{}
+		trieMap.length = 0
+		return removed
+	}
+	node := &trieMap.root
+	for len(prefix) > 0 {
+		i, ok := node.child(prefix[0])
+		if !ok {
+			return 0
+		}
+		child := node.children[i]
+		if len(prefix) <= len(child.prefix) {
+			if !trieHasPrefix(child.prefix, prefix) {
+				return 0
+			}
+			removed := child.length()
+			node.removeChild(i)
+			trieMap.length -= removed
+			if node != &trieMap.root {
+				node.compact()
+			}
+			return removed
+		}
+		if !trieHasPrefix(prefix, child.prefix) {
+			return 0
+		}
+		node = child
+		prefix = prefix[len(child.prefix):]
+	}
+	return 0
+}
+
+// Each executes the provided function for each key-value pair in the map in key order.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap(map[string]int{"banana": 3, "apple": 5})
+//	trieMap.Each(func(key string, value int) {
+//		fmt.Println(key, value) // apple 5, then banana 3
+//	})
+func (trieMap *TrieMap[V]) Each(fn func(key string, value V)) *TrieMap[V] {
+	return trieMap.EachBreak(func(key string, value V) bool {
+		fn(key, value)
+		return true
+	})
+}
+
+// EachBreak executes the provided function for each key-value pair in the map in key order
+// and stops when the function returns false.
+func (trieMap *TrieMap[V]) EachBreak(fn func(key string, value V) bool) *TrieMap[V] {
+	return trieMap.EachPrefix("", fn)
+}
+
+// EachPrefix executes the provided function for each key-value pair whose key starts with the prefix,
+// in key order, and stops when the function returns false.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap(map[string]int{"/api/users": 1, "/api/orders": 2, "/health": 3})
+//	trieMap.EachPrefix("/api/", func(key string, value int) bool {
+//		fmt.Println(key) // /api/orders, then /api/users
+//		return true
+//	})
+func (trieMap *TrieMap[V]) EachPrefix(prefix string, fn func(key string, value V) bool) *TrieMap[V] {
+	node, path := &trieMap.root, make([]byte, 0, len(prefix))
+	for len(prefix) > 0 {
+		i, ok := node.child(prefix[0])
+		if !ok {
+			return trieMap
+		}
+		child := node.children[i]
+		if len(prefix) <= len(child.prefix) {
+			if trieHasPrefix(child.prefix, prefix) {
+				child.each(path, fn)
+			}
+			return trieMap
+		}
+		if !trieHasPrefix(prefix, child.prefix) {
+			return trieMap
+		}
+		path = append(path, child.prefix...)
+		node = child
+		prefix = prefix[len(child.prefix):]
+	}
+	node.each(path[:len(path)-len(node.prefix)], fn)
+	return trieMap
+}
+
+// Fetch retrieves the value associated with the given key, or the zero value for the value type if the key is not present.
+func (trieMap *TrieMap[V]) Fetch(key string) V {
+	value, _ := trieMap.Get(key)
+	return value
+}
+
+// Get retrieves the value associated with the provided key and a boolean indicating whether the key exists.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap(map[string]int{"apple": 5})
+//	value, ok := trieMap.Get("apple") // 5, true
+func (trieMap *TrieMap[V]) Get(key string) (V, bool) {
+	if node, ok := trieGet(&trieMap.root, key); ok {
+		return node.value, true
+	}
+	var value V
+	return value, false
+}
+
+// GetBytes retrieves the value associated with the key given as a byte slice without converting it to a string.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap(map[string]int{"apple": 5})
+//	value, ok := trieMap.GetBytes([]byte("apple")) // 5, true
+func (trieMap *TrieMap[V]) GetBytes(key []byte) (V, bool) {
+	if node, ok := trieGet(&trieMap.root, key); ok {
+		return node.value, true
+	}
+	var value V
+	return value, false
+}
+
+// Has checks if the provided key exists in the map.
+func (trieMap *TrieMap[V]) Has(key string) bool {
+	_, ok := trieGet(&trieMap.root, key)
+	return ok
+}
+
+// HasBytes checks if the key given as a byte slice exists in the map.
+func (trieMap *TrieMap[V]) HasBytes(key []byte) bool {
+	_, ok := trieGet(&trieMap.root, key)
+	return ok
+}
+
+// IsEmpty checks if the map contains no key-value pairs.
+func (trieMap *TrieMap[V]) IsEmpty() bool {
+	return trieMap.Length() == 0
+}
+
+// Keys returns a slice containing all the keys present in the map in sorted order.
+func (trieMap *TrieMap[V]) Keys() *slice.Slice[string] {
+	keys := make(slice.Slice[string], 0, trieMap.Length())
+	trieMap.Each(func(key string, value V) {
+		keys.Append(key)
+	})
+	return &keys
+}
+
+// Length returns the number of key-value pairs in the map.
+func (trieMap *TrieMap[V]) Length() int {
+	return trieMap.length
+}
+
+// LongestPrefix returns the longest key in the map that is a prefix of s, along with its value.
+// It returns false if no key is a prefix of s.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap(map[string]string{"/": "root", "/api": "api"})
+//	key, value, ok := trieMap.LongestPrefix("/api/users") // "/api", "api", true
+func (trieMap *TrieMap[V]) LongestPrefix(s string) (string, V, bool) {
+	node := &trieMap.root
+	var value V
+	length, ok := 0, node.ok
+	if ok {
+		value = node.value
+	}
+	for consumed := 0; consumed < len(s); {
+		i, found := node.child(s[consumed])
+		if !found || !trieHasPrefix(s[consumed:], node.children[i].prefix) {
+			break
+		}
+		node = node.children[i]
+		consumed += len(node.prefix)
+		if node.ok {
+			length, ok, value = consumed, true, node.value
+		}
+	}
+	return s[:length], value, ok
+}
+
+// Map returns a new Map containing the key-value pairs of the TrieMap.
+func (trieMap *TrieMap[V]) Map() *Map[string, V] {
+	newMap := make(Map[string, V], trieMap.Length())
+	trieMap.Each(func(key string, value V) {
+		newMap.Add(key, value)
+	})
+	return &newMap
+}
+
+// Values returns a slice containing all the values present in the map, ordered by their keys.
+func (trieMap *TrieMap[V]) Values() *slice.Slice[V] {
+	values := make(slice.Slice[V], 0, trieMap.Length())
+	trieMap.Each(func(key string, value V) {
+		values.Append(value)
+	})
+	return &values
+}
+
+// WithPrefix returns a new TrieMap containing the key-value pairs whose keys start with the prefix.
+//
+//	// Create a new TrieMap instance.
+//	trieMap := gomap.NewTrieMap(map[string]int{"/api/users": 1, "/api/orders": 2, "/health": 3})
+//	api := trieMap.WithPrefix("/api/") // {"/api/orders": 2, "/api/users": 1}
+func (trieMap *TrieMap[V]) WithPrefix(prefix string) *TrieMap[V] {
+	newTrieMap := &TrieMap[V]{}
+	trieMap.EachPrefix(prefix, func(key string, value V) bool {
+		newTrieMap.Add(key, value)
+		return true
+	})
+	return newTrieMap
+}
+
+// prune removes a node that no longer holds a value or children and merges nodes left with a single child.
+func (trieMap *TrieMap[V]) prune(parent *trieNode[V], node *trieNode[V], index int) {
+	if parent == nil {
+		return
+	}
+	if len(node.children) == 0 {
+		parent.removeChild(index)
+		if parent != &trieMap.root {
+			parent.compact()
+		}
+		return
+	}
+	node.compact()
+}
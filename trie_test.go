@@ -0,0 +1,187 @@
+package gomap_test
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// TestTrieMapAdd tests TrieMap.Add.
+func TestTrieMapAdd(t *testing.T) {
+	trieMap := gomap.NewTrieMap[int]()
+	trieMap.Add("romane", 1).Add("romanus", 2).Add("romulus", 3).Add("rom", 4).Add("", 5).Add("romane", 6)
+
+	expected := map[string]int{"": 5, "rom": 4, "romane": 6, "romanus": 2, "romulus": 3}
+	if trieMap.Length() != len(expected) {
+		t.Errorf("Expected length %d, but got %d", len(expected), trieMap.Length())
+	}
+	for key, value := range expected {
+		if v, ok := trieMap.Get(key); !ok || v != value {
+			t.Errorf("Expected %q to be %d, but got %d, %t", key, value, v, ok)
+		}
+	}
+	for _, key := range []string{"r", "ro", "roman", "romanes", "x"} {
+		if trieMap.Has(key) {
+			t.Errorf("Expected %q to be absent", key)
+		}
+	}
+	if value, ok := trieMap.GetBytes([]byte("romulus")); !ok || value != 3 {
+		t.Errorf("Expected 3, but got %d, %t", value, ok)
+	}
+	if !trieMap.HasBytes([]byte("rom")) || trieMap.HasBytes([]byte("roma")) {
+		t.Errorf("Expected HasBytes to match Has")
+	}
+}
+
+// TestTrieMapDelete tests TrieMap.Delete and TrieMap.DeleteOK.
+func TestTrieMapDelete(t *testing.T) {
+	trieMap := gomap.NewTrieMap(map[string]int{"rom": 1, "romane": 2, "romanus": 3})
+	if trieMap.DeleteOK("roman") {
+		t.Errorf("Expected DeleteOK to report 'roman' as absent")
+	}
+	if !trieMap.DeleteOK("romane") {
+		t.Errorf("Expected DeleteOK to report 'romane' as present")
+	}
+	trieMap.Delete("rom")
+	if trieMap.Length() != 1 || trieMap.Fetch("romanus") != 3 {
+		t.Errorf("Expected only 'romanus' to remain, but got %v", trieMap.Map())
+	}
+	trieMap.Delete("romanus")
+	if !trieMap.IsEmpty() {
+		t.Errorf("Expected the map to be empty, but got %v", trieMap.Map())
+	}
+}
+
+// TestTrieMapEach tests that TrieMap iterates in key order.
+func TestTrieMapEach(t *testing.T) {
+	trieMap := gomap.NewTrieMap(map[string]int{"b": 2, "ab": 1, "a": 0, "ba": 3, "c": 4})
+
+	expected := []string{"a", "ab", "b", "ba", "c"}
+	if keys := []string(*trieMap.Keys()); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+	if values := []int(*trieMap.Values()); !reflect.DeepEqual(values, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("Expected values in key order, but got %v", values)
+	}
+	var visited []string
+	trieMap.EachBreak(func(key string, value int) bool {
+		visited = append(visited, key)
+		return key != "b"
+	})
+	if !reflect.DeepEqual(visited, expected[:3]) {
+		t.Errorf("Expected %v, but got %v", expected[:3], visited)
+	}
+}
+
+// TestTrieMapWithPrefix tests TrieMap.WithPrefix and TrieMap.EachPrefix.
+func TestTrieMapWithPrefix(t *testing.T) {
+	trieMap := gomap.NewTrieMap(map[string]int{"/api/users": 1, "/api/orders": 2, "/api": 3, "/health": 4})
+	tests := []struct {
+		prefix   string
+		expected []string
+	}{
+		{"/api/", []string{"/api/orders", "/api/users"}},
+		{"/api", []string{"/api", "/api/orders", "/api/users"}},
+		{"/ap", []string{"/api", "/api/orders", "/api/users"}},
+		{"/api/users", []string{"/api/users"}},
+		{"/api/users/1", nil},
+		{"/x", nil},
+		{"", []string{"/api", "/api/orders", "/api/users", "/health"}},
+	}
+	for _, test := range tests {
+		keys := []string(*trieMap.WithPrefix(test.prefix).Keys())
+		if len(keys) != len(test.expected) || len(keys) > 0 && !reflect.DeepEqual(keys, test.expected) {
+			t.Errorf("WithPrefix(%q): expected %v, but got %v", test.prefix, test.expected, keys)
+		}
+	}
+}
+
+// TestTrieMapLongestPrefix tests TrieMap.LongestPrefix.
+func TestTrieMapLongestPrefix(t *testing.T) {
+	trieMap := gomap.NewTrieMap(map[string]string{"/": "root", "/api": "api", "/api/users": "users"})
+	tests := []struct {
+		s        string
+		key      string
+		expected string
+		ok       bool
+	}{
+		{"/api/users/1", "/api/users", "users", true},
+		{"/api/orders", "/api", "api", true},
+		{"/apix", "/api", "api", true},
+		{"/health", "/", "root", true},
+		{"health", "", "", false},
+	}
+	for _, test := range tests {
+		key, value, ok := trieMap.LongestPrefix(test.s)
+		if key != test.key || value != test.expected || ok != test.ok {
+			t.Errorf("LongestPrefix(%q): expected %q, %q, %t, but got %q, %q, %t", test.s, test.key, test.expected, test.ok, key, value, ok)
+		}
+	}
+}
+
+// TestTrieMapDeletePrefix tests TrieMap.DeletePrefix.
+func TestTrieMapDeletePrefix(t *testing.T) {
+	trieMap := gomap.NewTrieMap(map[string]int{"/api/users": 1, "/api/orders": 2, "/api": 3, "/health": 4})
+	if removed := trieMap.DeletePrefix("/api/"); removed != 2 {
+		t.Errorf("Expected 2 keys to be removed, but got %d", removed)
+	}
+	if removed := trieMap.DeletePrefix("/x"); removed != 0 {
+		t.Errorf("Expected no keys to be removed, but got %d", removed)
+	}
+	expected := []string{"/api", "/health"}
+	if keys := []string(*trieMap.Keys()); !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected %v, but got %v", expected, keys)
+	}
+	if removed := trieMap.DeletePrefix(""); removed != 2 || !trieMap.IsEmpty() {
+		t.Errorf("Expected all keys to be removed, but got %d", removed)
+	}
+}
+
+// TestTrieMapRandom tests TrieMap against a Map using random operations.
+func TestTrieMapRandom(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomKey := func() string {
+		var builder strings.Builder
+		for i := random.Intn(6); i > 0; i-- {
+			builder.WriteByte("abc"[random.Intn(3)])
+		}
+		return builder.String()
+	}
+	trieMap, reference := gomap.NewTrieMap[int](), &gomap.Map[string, int]{}
+	for i := 0; i < 5000; i++ {
+		key := randomKey()
+		switch random.Intn(4) {
+		case 0, 1:
+			trieMap.Add(key, i)
+			reference.Add(key, i)
+		case 2:
+			if trieMap.DeleteOK(key) != reference.Has(key) {
+				t.Fatalf("DeleteOK(%q) disagrees with the reference", key)
+			}
+			reference.Delete(key)
+		case 3:
+			reference.DeleteManyFunc(func(k string, value int) bool {
+				return strings.HasPrefix(k, key)
+			})
+			count := trieMap.Length() - reference.Length()
+			if got := trieMap.DeletePrefix(key); got != count {
+				t.Fatalf("DeletePrefix(%q): expected %d, but got %d", key, count, got)
+			}
+		}
+		if trieMap.Length() != reference.Length() {
+			t.Fatalf("Expected length %d, but got %d", reference.Length(), trieMap.Length())
+		}
+	}
+	keys := []string(*reference.Keys())
+	sort.Strings(keys)
+	if got := []string(*trieMap.Keys()); len(keys) != len(got) || len(keys) > 0 && !reflect.DeepEqual(got, keys) {
+		t.Errorf("Expected %v, but got %v", keys, got)
+	}
+	if !trieMap.Map().Equal(reference) {
+		t.Errorf("Expected %v, but got %v", reference, trieMap.Map())
+	}
+}
@@ -0,0 +1,483 @@
+package gomap
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/lindsaygelle/slice"
+)
+
+// View is a read-only view of a Map. It exposes only the methods of Map that do not modify it,
+// so code holding a View cannot add, replace or delete key-value pairs.
+// Methods that return a new Map, such as Filter and Intersection, return an independent copy.
+//
+// A View returned by ReadOnly reflects later changes to the Map. A View returned by Freeze is a snapshot
+// holding deep copies of the values, except that unexported struct fields are copied by assignment;
+// maps, slices and pointers held in unexported fields remain shared with the original values.
+type View[K comparable, V any] interface {
+	Contains(value V) (K, bool)
+	Each(fn func(key K, value V)) View[K, V]
+	EachBreak(fn func(key K, value V) bool) View[K, V]
+	EachKey(fn func(key K)) View[K, V]
+	EachKeyBreak(fn func(key K) bool) View[K, V]
+	EachValue(fn func(value V)) View[K, V]
+	EachValueBreak(fn func(value V) bool) View[K, V]
+	Equal(other *Map[K, V]) bool
+	EqualFunc(other *Map[K, V], fn func(a V, b V) bool) bool
+	EqualLength(other *Map[K, V]) bool
+	Fetch(key K) V
+	Filter(fn func(key K, value V) bool) *Map[K, V]
+	Get(key K) (V, bool)
+	GetMany(keys ...K) *slice.Slice[V]
+	Has(key K) bool
+	HasMany(keys ...K) *slice.Slice[bool]
+	Immutable() *ImmutableMap[K, V]
+	Intersection(other *Map[K, V]) *Map[K, V]
+	IntersectionFunc(other *Map[K, V], fn func(key K, a V, b V) bool) *Map[K, V]
+	IsEmpty() bool
+	IsPopulated() bool
+	Keys() *slice.Slice[K]
+	KeysFunc(fn func(key K) bool) *slice.Slice[K]
+	Length() int
+	Not(key K) bool
+	NotMany(keys ...K) *slice.Slice[bool]
+	Values() *slice.Slice[V]
+	ValuesFunc(fn func(key K, value V) bool) *slice.Slice[V]
+}
+
+// view implements View by delegating to the non-mutating methods of a Map.
+type view[K comparable, V any] struct {
+	gomap *Map[K, V]
+}
+
+func (view *view[K, V]) Contains(value V) (K, bool) {
+	return view.gomap.Contains(value)
+}
+
+func (view *view[K, V]) Each(fn func(key K, value V)) View[K, V] {
+	view.gomap.Each(fn)
+	return view
+}
+
+func (view *view[K, V]) EachBreak(fn func(key K, value V) bool) View[K, V] {
+	view.gomap.EachBreak(fn)
+	return view
+}
+
+func (view *view[K, V]) EachKey(fn func(key K)) View[K, V] {
+	view.gomap.EachKey(fn)
+	return view
+}
+
+func (view *view[K, V]) EachKeyBreak(fn func(key K) bool) View[K, V] {
+	view.gomap.EachKeyBreak(fn)
+	return view
+}
+
+func (view *view[K, V]) EachValue(fn func(value V)) View[K, V] {
+	view.gomap.EachValue(fn)
+	return view
+}
+
+func (view *view[K, V]) EachValueBreak(fn func(value V) bool) View[K, V] {
+	view.gomap.EachValueBreak(fn)
+	return view
+}
+
+func (view *view[K, V]) Equal(other *Map[K, V]) bool {
+	return view.gomap.Equal(other)
+}
+
+func (view *view[K, V]) EqualFunc(other *Map[K, V], fn func(a V, b V) bool) bool {
+	return view.gomap.EqualFunc(other, fn)
+}
+
+func (view *view[K, V]) EqualLength(other *Map[K, V]) bool {
+	return view.gomap.EqualLength(other)
+}
+
+func (view *view[K, V]) Fetch(key K) V {
+	return view.gomap.Fetch(key)
+}
+
+func (view *view[K, V]) Filter(fn func(key K, value V) bool) *Map[K, V] {
+	return view.gomap.Filter(fn)
+}
+
+func (view *view[K, V]) Get(key K) (V, bool) {
+	return view.gomap.Get(key)
+}
+
+func (view *view[K, V]) GetMany(keys ...K) *slice.Slice[V] {
+	return view.gomap.GetMany(keys...)
+}
+
+func (view *view[K, V]) Has(key K) bool {
+	return view.gomap.Has(key)
+}
+
+func (view *view[K, V]) HasMany(keys ...K) *slice.Slice[bool] {
+	return view.gomap.HasMany(keys...)
+}
+
+func (view *view[K, V]) Immutable() *ImmutableMap[K, V] {
+	return view.gomap.Immutable()
+}
+
+func (view *view[K, V]) Intersection(other *Map[K, V]) *Map[K, V] {
+	return view.gomap.Intersection(other)
+}
+
+func (view *view[K, V]) IntersectionFunc(other *Map[K, V], fn func(key K, a V, b V) bool) *Map[K, V] {
+	return view.gomap.IntersectionFunc(other, fn)
+}
+
+func (view *view[K, V]) IsEmpty() bool {
+	return view.gomap.IsEmpty()
+}
+
+func (view *view[K, V]) IsPopulated() bool {
+	return view.gomap.IsPopulated()
+}
+
+func (view *view[K, V]) Keys() *slice.Slice[K] {
+	return view.gomap.Keys()
+}
+
+func (view *view[K, V]) KeysFunc(fn func(key K) bool) *slice.Slice[K] {
+	return view.gomap.KeysFunc(fn)
+}
+
+func (view *view[K, V]) Length() int {
+	return view.gomap.Length()
+}
+
+func (view *view[K, V]) Not(key K) bool {
+	return view.gomap.Not(key)
+}
+
+func (view *view[K, V]) NotMany(keys ...K) *slice.Slice[bool] {
+	return view.gomap.NotMany(keys...)
+}
+
+func (view *view[K, V]) Values() *slice.Slice[V] {
+	return view.gomap.Values()
+}
+
+func (view *view[K, V]) ValuesFunc(fn func(key K, value V) bool) *slice.Slice[V] {
+	return view.gomap.ValuesFunc(fn)
+}
+
+// frozenView implements View for Freeze. It returns deep copies of the values of the snapshot,
+// so that callers cannot modify the snapshot through the maps, slices and pointers the values hold.
+type frozenView[K comparable, V any] struct {
+	*view[K, V]
+}
+
+// thaw returns a copy of the snapshot with deep copies of its values.
+func (frozen *frozenView[K, V]) thaw() *Map[K, V] {
+	return deepCopyValues(frozen.gomap)
+}
+
+func (frozen *frozenView[K, V]) Each(fn func(key K, value V)) View[K, V] {
+	frozen.thaw().Each(fn)
+	return frozen
+}
+
+func (frozen *frozenView[K, V]) EachBreak(fn func(key K, value V) bool) View[K, V] {
+	frozen.thaw().EachBreak(fn)
+	return frozen
+}
+
+func (frozen *frozenView[K, V]) EachKey(fn func(key K)) View[K, V] {
+	frozen.gomap.EachKey(fn)
+	return frozen
+}
+
+func (frozen *frozenView[K, V]) EachKeyBreak(fn func(key K) bool) View[K, V] {
+	frozen.gomap.EachKeyBreak(fn)
+	return frozen
+}
+
+func (frozen *frozenView[K, V]) EachValue(fn func(value V)) View[K, V] {
+	frozen.thaw().EachValue(fn)
+	return frozen
+}
+
+func (frozen *frozenView[K, V]) EachValueBreak(fn func(value V) bool) View[K, V] {
+	frozen.thaw().EachValueBreak(fn)
+	return frozen
+}
+
+func (frozen *frozenView[K, V]) EqualFunc(other *Map[K, V], fn func(a V, b V) bool) bool {
+	return frozen.thaw().EqualFunc(other, fn)
+}
+
+func (frozen *frozenView[K, V]) Fetch(key K) V {
+	return deepCopyOf(frozen.gomap.Fetch(key))
+}
+
+func (frozen *frozenView[K, V]) Filter(fn func(key K, value V) bool) *Map[K, V] {
+	return frozen.thaw().Filter(fn)
+}
+
+func (frozen *frozenView[K, V]) Get(key K) (V, bool) {
+	value, ok := frozen.gomap.Get(key)
+	return deepCopyOf(value), ok
+}
+
+func (frozen *frozenView[K, V]) GetMany(keys ...K) *slice.Slice[V] {
+	return deepCopyOf(frozen.gomap.GetMany(keys...))
+}
+
+func (frozen *frozenView[K, V]) Immutable() *ImmutableMap[K, V] {
+	return frozen.thaw().Immutable()
+}
+
+func (frozen *frozenView[K, V]) Intersection(other *Map[K, V]) *Map[K, V] {
+	return frozen.thaw().Intersection(other)
+}
+
+func (frozen *frozenView[K, V]) IntersectionFunc(other *Map[K, V], fn func(key K, a V, b V) bool) *Map[K, V] {
+	return frozen.thaw().IntersectionFunc(other, fn)
+}
+
+func (frozen *frozenView[K, V]) Values() *slice.Slice[V] {
+	return deepCopyOf(frozen.gomap.Values())
+}
+
+func (frozen *frozenView[K, V]) ValuesFunc(fn func(key K, value V) bool) *slice.Slice[V] {
+	return frozen.thaw().ValuesFunc(fn)
+}
+
+// immutableView implements View for COWMap.Snapshot. Lookups and iteration read the ImmutableMap directly;
+// the other methods use a Map holding the same key-value pairs, which is built the first time one of them is called.
+type immutableView[K comparable, V any] struct {
+	immutableMap *ImmutableMap[K, V]
+	once         sync.Once
+	view         *view[K, V]
+}
+
+// mapView returns a view of a Map holding the key-value pairs of the ImmutableMap.
+func (immutable *immutableView[K, V]) mapView() *view[K, V] {
+	immutable.once.Do(func() {
+		immutable.view = &view[K, V]{gomap: immutable.immutableMap.Map()}
+	})
+	return immutable.view
+}
+
+func (immutable *immutableView[K, V]) Contains(value V) (K, bool) {
+	return immutable.mapView().Contains(value)
+}
+
+func (immutable *immutableView[K, V]) Each(fn func(key K, value V)) View[K, V] {
+	immutable.immutableMap.Each(fn)
+	return immutable
+}
+
+func (immutable *immutableView[K, V]) EachBreak(fn func(key K, value V) bool) View[K, V] {
+	immutable.immutableMap.EachBreak(fn)
+	return immutable
+}
+
+func (immutable *immutableView[K, V]) EachKey(fn func(key K)) View[K, V] {
+	immutable.immutableMap.Each(func(key K, _ V) { fn(key) })
+	return immutable
+}
+
+func (immutable *immutableView[K, V]) EachKeyBreak(fn func(key K) bool) View[K, V] {
+	immutable.immutableMap.EachBreak(func(key K, _ V) bool { return fn(key) })
+	return immutable
+}
+
+func (immutable *immutableView[K, V]) EachValue(fn func(value V)) View[K, V] {
+	immutable.immutableMap.Each(func(_ K, value V) { fn(value) })
+	return immutable
+}
+
+func (immutable *immutableView[K, V]) EachValueBreak(fn func(value V) bool) View[K, V] {
+	immutable.immutableMap.EachBreak(func(_ K, value V) bool { return fn(value) })
+	return immutable
+}
+
+func (immutable *immutableView[K, V]) Equal(other *Map[K, V]) bool {
+	return immutable.mapView().Equal(other)
+}
+
+func (immutable *immutableView[K, V]) EqualFunc(other *Map[K, V], fn func(a V, b V) bool) bool {
+	return immutable.mapView().EqualFunc(other, fn)
+}
+
+func (immutable *immutableView[K, V]) EqualLength(other *Map[K, V]) bool {
+	return immutable.immutableMap.Length() == other.Length()
+}
+
+func (immutable *immutableView[K, V]) Fetch(key K) V {
+	return immutable.immutableMap.Fetch(key)
+}
+
+func (immutable *immutableView[K, V]) Filter(fn func(key K, value V) bool) *Map[K, V] {
+	return immutable.mapView().Filter(fn)
+}
+
+func (immutable *immutableView[K, V]) Get(key K) (V, bool) {
+	return immutable.immutableMap.Get(key)
+}
+
+func (immutable *immutableView[K, V]) GetMany(keys ...K) *slice.Slice[V] {
+	return immutable.mapView().GetMany(keys...)
+}
+
+func (immutable *immutableView[K, V]) Has(key K) bool {
+	return immutable.immutableMap.Has(key)
+}
+
+func (immutable *immutableView[K, V]) HasMany(keys ...K) *slice.Slice[bool] {
+	return immutable.mapView().HasMany(keys...)
+}
+
+func (immutable *immutableView[K, V]) Immutable() *ImmutableMap[K, V] {
+	return immutable.immutableMap
+}
+
+func (immutable *immutableView[K, V]) Intersection(other *Map[K, V]) *Map[K, V] {
+	return immutable.mapView().Intersection(other)
+}
+
+func (immutable *immutableView[K, V]) IntersectionFunc(other *Map[K, V], fn func(key K, a V, b V) bool) *Map[K, V] {
+	return immutable.mapView().IntersectionFunc(other, fn)
+}
+
+func (immutable *immutableView[K, V]) IsEmpty() bool {
+	return immutable.immutableMap.IsEmpty()
+}
+
+func (immutable *immutableView[K, V]) IsPopulated() bool {
+	return immutable.immutableMap.IsPopulated()
+}
+
+func (immutable *immutableView[K, V]) Keys() *slice.Slice[K] {
+	return immutable.immutableMap.Keys()
+}
+
+func (immutable *immutableView[K, V]) KeysFunc(fn func(key K) bool) *slice.Slice[K] {
+	return immutable.mapView().KeysFunc(fn)
+}
+
+func (immutable *immutableView[K, V]) Length() int {
+	return immutable.immutableMap.Length()
+}
+
+func (immutable *immutableView[K, V]) Not(key K) bool {
+	return !immutable.immutableMap.Has(key)
+}
+
+func (immutable *immutableView[K, V]) NotMany(keys ...K) *slice.Slice[bool] {
+	return immutable.mapView().NotMany(keys...)
+}
+
+func (immutable *immutableView[K, V]) Values() *slice.Slice[V] {
+	return immutable.immutableMap.Values()
+}
+
+func (immutable *immutableView[K, V]) ValuesFunc(fn func(key K, value V) bool) *slice.Slice[V] {
+	return immutable.mapView().ValuesFunc(fn)
+}
+
+// deepCopyKey identifies a map or pointer that deepCopy has already copied. The type is part of the key
+// because pointers of different types can share an address, such as a pointer to a struct and to its first field.
+type deepCopyKey struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// deepCopyOf returns a deep copy of the value using deepCopy.
+func deepCopyOf[T any](value T) T {
+	var newValue T
+	reflect.ValueOf(&newValue).Elem().Set(deepCopy(reflect.ValueOf(&value).Elem(), make(map[deepCopyKey]reflect.Value)))
+	return newValue
+}
+
+// deepCopyValues returns a copy of the map with deep copies of its values. Keys are copied by assignment,
+// so that keys holding pointers keep their identity.
+func deepCopyValues[K comparable, V any](gomap *Map[K, V]) *Map[K, V] {
+	visited := make(map[deepCopyKey]reflect.Value)
+	newMap := make(Map[K, V], gomap.Length())
+	gomap.Each(func(key K, value V) {
+		var newValue V
+		reflect.ValueOf(&newValue).Elem().Set(deepCopy(reflect.ValueOf(&value).Elem(), visited))
+		newMap.Add(key, newValue)
+	})
+	return &newMap
+}
+
+// deepCopy returns a copy of the value that shares no maps, slices or pointers with the original.
+// Unexported struct fields are copied by assignment, as reflect cannot set them, and deep copying them would
+// also duplicate internals whose identity matters, such as the *time.Location of a time.Time. Cycles are preserved through the visited pointers.
+// Pointers to zero-size values are not recorded, because distinct zero-size values may share an address.
+func deepCopy(value reflect.Value, visited map[deepCopyKey]reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Array:
+		newValue := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			newValue.Index(i).Set(deepCopy(value.Index(i), visited))
+		}
+		return newValue
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		newValue := reflect.New(value.Type()).Elem()
+		newValue.Set(deepCopy(value.Elem(), visited))
+		return newValue
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		key := deepCopyKey{ptr: value.Pointer(), typ: value.Type()}
+		if newValue, ok := visited[key]; ok {
+			return newValue
+		}
+		newValue := reflect.MakeMapWithSize(value.Type(), value.Len())
+		visited[key] = newValue
+		iterator := value.MapRange()
+		for iterator.Next() {
+			newValue.SetMapIndex(deepCopy(iterator.Key(), visited), deepCopy(iterator.Value(), visited))
+		}
+		return newValue
+	case reflect.Pointer:
+		if value.IsNil() {
+			return value
+		}
+		key := deepCopyKey{ptr: value.Pointer(), typ: value.Type()}
+		if newValue, ok := visited[key]; ok {
+			return newValue
+		}
+		newValue := reflect.New(value.Type().Elem())
+		if value.Type().Elem().Size() > 0 {
+			visited[key] = newValue
+		}
+		newValue.Elem().Set(deepCopy(value.Elem(), visited))
+		return newValue
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		newValue := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			newValue.Index(i).Set(deepCopy(value.Index(i), visited))
+		}
+		return newValue
+	case reflect.Struct:
+		newValue := reflect.New(value.Type()).Elem()
+		newValue.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if newValue.Field(i).CanSet() {
+				newValue.Field(i).Set(deepCopy(value.Field(i), visited))
+			}
+		}
+		return newValue
+	default:
+		return value
+	}
+}
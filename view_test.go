@@ -0,0 +1,126 @@
+package gomap_test
+
+import (
+	"testing"
+
+	"github.com/lindsaygelle/gomap"
+)
+
+// TestReadOnly tests Map.ReadOnly.
+func TestReadOnly(t *testing.T) {
+	newMap := &gomap.Map[string, int]{"apple": 5}
+	readOnly := newMap.ReadOnly()
+
+	// Changes to the map are visible through the view.
+	newMap.Add("banana", 3)
+	if readOnly.Length() != 2 || readOnly.Fetch("banana") != 3 {
+		t.Errorf("Expected view to reflect changes to the map, but got length %d", readOnly.Length())
+	}
+
+	// The view does not expose mutating methods.
+	if _, ok := readOnly.(interface {
+		Add(key string, value int) *gomap.Map[string, int]
+	}); ok {
+		t.Errorf("Expected view not to expose Add")
+	}
+
+	// Iteration methods return the view for chaining.
+	count := 0
+	readOnly.Each(func(key string, value int) {
+		count++
+	}).EachKey(func(key string) {
+		count++
+	})
+	if count != 4 {
+		t.Errorf("Expected 4 callbacks, but got %d", count)
+	}
+
+	// Filter returns an independent map.
+	filtered := readOnly.Filter(func(key string, value int) bool {
+		return value > 4
+	})
+	filtered.Add("cherry", 8)
+	if newMap.Has("cherry") {
+		t.Errorf("Expected filtered map to be independent of the original map")
+	}
+	if key, ok := readOnly.Contains(3); !ok || key != "banana" {
+		t.Errorf("Expected Contains(3) to return 'banana', but got %q", key)
+	}
+}
+
+// TestFreeze tests Map.Freeze.
+func TestFreeze(t *testing.T) {
+	type record struct {
+		Tags  []string
+		Attrs map[string]int
+		Next  *record
+	}
+	newMap := &gomap.Map[string, record]{
+		"apple": {Tags: []string{"red"}, Attrs: map[string]int{"weight": 5}, Next: &record{Tags: []string{"seed"}}}}
+	snapshot := newMap.Freeze()
+
+	// Change the map and the values it holds.
+	value := newMap.Fetch("apple")
+	value.Tags[0] = "green"
+	value.Attrs["weight"] = 10
+	value.Next.Tags[0] = "pit"
+	newMap.Add("banana", record{})
+
+	frozen := snapshot.Fetch("apple")
+	if snapshot.Length() != 1 {
+		t.Errorf("Expected snapshot length 1, but got %d", snapshot.Length())
+	}
+	if frozen.Tags[0] != "red" || frozen.Attrs["weight"] != 5 || frozen.Next.Tags[0] != "seed" {
+		t.Errorf("Expected snapshot to be unaffected by changes to nested values, but got %+v", frozen)
+	}
+}
+
+// TestFreezeCycle tests Map.Freeze with cyclic values.
+func TestFreezeCycle(t *testing.T) {
+	type node struct {
+		Next *node
+	}
+	cycle := &node{}
+	cycle.Next = cycle
+	newMap := &gomap.Map[string, *node]{"cycle": cycle}
+	frozen := newMap.Freeze().Fetch("cycle")
+	if frozen == cycle || frozen.Next != frozen {
+		t.Errorf("Expected frozen cycle to be copied and preserved")
+	}
+}
+
+// TestFreezeReturnsCopies tests that values read from a frozen View cannot change the snapshot.
+func TestFreezeReturnsCopies(t *testing.T) {
+	newMap := &gomap.Map[string, []int]{"apple": {5}}
+	snapshot := newMap.Freeze()
+	snapshot.Fetch("apple")[0] = 1
+	value, _ := snapshot.Get("apple")
+	value[0] = 2
+	(*snapshot.Values())[0][0] = 3
+	(*snapshot.GetMany("apple"))[0][0] = 4
+	snapshot.Each(func(key string, value []int) { value[0] = 5 })
+	snapshot.EachValue(func(value []int) { value[0] = 6 })
+	snapshot.Filter(func(key string, value []int) bool { return true }).Fetch("apple")[0] = 7
+	if value := snapshot.Fetch("apple"); value[0] != 5 || (*newMap)["apple"][0] != 5 {
+		t.Errorf("Expected the snapshot to be unchanged, but got %v", value)
+	}
+}
+
+// TestFreezeSharedAddresses tests Map.Freeze with pointers of different types that share an address.
+func TestFreezeSharedAddresses(t *testing.T) {
+	type inner struct {
+		N int
+	}
+	type outer struct {
+		Inner *inner
+		N     *int
+		Empty *struct{}
+		Array *[0]int
+	}
+	value := &inner{N: 1}
+	newMap := &gomap.Map[string, outer]{"a": {Inner: value, N: &value.N, Empty: &struct{}{}, Array: &[0]int{}}}
+	frozen := newMap.Freeze().Fetch("a")
+	if frozen.Inner == value || frozen.Inner.N != 1 || *frozen.N != 1 || frozen.Empty == nil || frozen.Array == nil {
+		t.Errorf("Expected the values to be copied, but got %+v", frozen)
+	}
+}